@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
@@ -20,13 +21,54 @@ const (
 	ValueError = "value error"
 	// OutOfBoundsError is used when indexing an object outside of it's range
 	OutOfBoundsError = "out of bounds error"
+	// PortError is used when a port can't perform the requested operation
+	PortError = "port error"
+	// FileError is used when the file system can't perform the requested operation
+	FileError = "file error"
+	// ThreadError is used when a thread or a synchronization object is misused
+	ThreadError = "thread error"
+	// ChannelError is used when a channel can't perform the requested operation
+	ChannelError = "channel error"
+	// InterruptError is used when an evaluation is cancelled or runs past its deadline
+	InterruptError = "interrupt error"
+	// AllocationLimitError is used when an evaluation allocates more than it's allowed to
+	AllocationLimitError = "allocation limit error"
+	// CallDepthLimitError is used when an evaluation nests more calls than it's allowed to
+	CallDepthLimitError = "call depth limit error"
+	// StepLimitError is used when an evaluation takes more steps than it's allowed to
+	StepLimitError = "step limit error"
 )
 
+// Debug makes new errors capture the go stack, which is only useful to debug the interpreter itself
+var Debug = false
+
+// Frame is a procedure call of a scheme backtrace
+type Frame struct {
+	Procedure string
+	Source    string
+	Line      int
+	Column    int
+}
+
+// String makes a descriptive string from a Frame
+func (frame Frame) String() string {
+	name := frame.Procedure
+	if name == "" {
+		name = "<anonymous>"
+	}
+	if frame.Source == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s:%d:%d)", name, frame.Source, frame.Line, frame.Column)
+}
+
 // InterpreterError is the error type for the implementation of scheme
 type InterpreterError struct {
 	Name        ErrorName
 	Description string
 	Irritants   []interface{}
+	Cause       error
+	Backtrace   []Frame
 	Stack       []byte
 }
 
@@ -36,9 +78,17 @@ func (err *InterpreterError) Error() string {
 	for i, irr := range err.Irritants {
 		strs[i] = fmt.Sprintf("%v", irr)
 	}
+	if err.Cause != nil {
+		return fmt.Sprintf("%s (%s) %s: %v", err.Name, strings.Join(strs, " "), err.Description, err.Cause)
+	}
 	return fmt.Sprintf("%s (%s) %s", err.Name, strings.Join(strs, " "), err.Description)
 }
 
+// Unwrap returns the go error that caused an InterpreterError
+func (err *InterpreterError) Unwrap() error {
+	return err.Cause
+}
+
 // NewError is an InterpreterError constructor
 func NewError(name ErrorName, description string, irritants ...interface{}) error {
 	err := &InterpreterError{
@@ -46,7 +96,84 @@ func NewError(name ErrorName, description string, irritants ...interface{}) erro
 		Description: description,
 		Irritants:   irritants,
 	}
-	err.Stack = make([]byte, 800)
-	runtime.Stack(err.Stack, false)
+	if Debug {
+		err.Stack = goStack()
+	}
 	return err
 }
+
+// goStack returns the whole go stack of the calling goroutine
+func goStack() []byte {
+	buf := make([]byte, 1024)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// AddFrame returns a copy of an error recording a procedure call it unwound through, innermost calls
+// are added first. The given error is left untouched since it may be returned more than once.
+// Errors other than InterpreterError are wrapped into one
+func AddFrame(err error, frame Frame) error {
+	if err == nil {
+		return nil
+	}
+	ierr, ok := err.(*InterpreterError)
+	if !ok {
+		ierr = WrapError(UnexpectedError, "go error", err).(*InterpreterError)
+	}
+	copied := *ierr
+	copied.Backtrace = make([]Frame, len(ierr.Backtrace), len(ierr.Backtrace)+1)
+	copy(copied.Backtrace, ierr.Backtrace)
+	copied.Backtrace = append(copied.Backtrace, frame)
+	return &copied
+}
+
+// ConditionBacktrace returns the scheme backtrace of an error, innermost call first
+func ConditionBacktrace(err error) []Frame {
+	ierr, ok := err.(*InterpreterError)
+	if !ok {
+		return nil
+	}
+	return ierr.Backtrace
+}
+
+// FormatBacktrace makes a printable multi-line string from the scheme backtrace of an error
+func FormatBacktrace(err error) string {
+	frames := ConditionBacktrace(err)
+	lines := make([]string, len(frames))
+	for i, frame := range frames {
+		lines[i] = fmt.Sprintf("  %d: %s", i, frame)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WrapError is an InterpreterError constructor for failures caused by a go error
+func WrapError(name ErrorName, description string, cause error, irritants ...interface{}) error {
+	err := NewError(name, description, irritants...).(*InterpreterError)
+	err.Cause = cause
+	return err
+}
+
+// IsFileError reports whether an error is an InterpreterError raised by the file system
+func IsFileError(err error) bool {
+	ierr, ok := err.(*InterpreterError)
+	return ok && ierr.Name == FileError
+}
+
+// CheckInterrupt returns an InterruptError wrapping the reason of a finished context, it's meant
+// to be called at the safe points of an evaluation
+func CheckInterrupt(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return WrapError(InterruptError, "evaluation interrupted", ctx.Err())
+	default:
+		return nil
+	}
+}
@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"context"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,3 +16,75 @@ func TestInterpreterError(t *testing.T) {
 	assert.Contains(t, err1.Error(), "foo: 1", "it should contain the key value irritant")
 	assert.Contains(t, err1.Error(), "bar: 2", "it should contain the key value irritant")
 }
+
+func TestWrapError(t *testing.T) {
+	cause := &os.PathError{Op: "open", Path: "/nowhere", Err: os.ErrNotExist}
+	err := WrapError(FileError, "couldn't open", cause, "name:", "/nowhere")
+	assert.Error(t, err, "it should be an error")
+	assert.Contains(t, err.Error(), FileError, "it should contain the error type")
+	assert.Contains(t, err.Error(), "name: /nowhere", "it should contain the key value irritant")
+	assert.Contains(t, err.Error(), cause.Error(), "it should contain the cause")
+	assert.True(t, err.(*InterpreterError).Unwrap() == cause, "it should wrap the cause")
+	assert.True(t, IsFileError(err), "it should be a file error")
+
+	assert.False(t, IsFileError(NewError(ValueError, "bad value")), "it shouldn't be a file error")
+	assert.False(t, IsFileError(cause), "it shouldn't be a file error")
+	assert.Nil(t, NewError(ValueError, "bad value").(*InterpreterError).Unwrap(), "it shouldn't have a cause")
+}
+
+func TestCheckInterrupt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, CheckInterrupt(ctx), "it shouldn't be an error")
+	assert.NoError(t, CheckInterrupt(nil), "it shouldn't be an error")
+
+	cancel()
+	err := CheckInterrupt(ctx)
+	assert.Error(t, err, "it should be an error")
+	assert.Contains(t, err.Error(), InterruptError, "it should contain the error type")
+	assert.True(t, err.(*InterpreterError).Cause == context.Canceled, "it should wrap the reason")
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+	err = CheckInterrupt(ctx)
+	assert.True(t, err.(*InterpreterError).Cause == context.DeadlineExceeded, "it should wrap the reason")
+}
+
+func TestBacktrace(t *testing.T) {
+	err := NewError(ValueError, "bad value", "x:", 1)
+	assert.Nil(t, err.(*InterpreterError).Stack, "it shouldn't capture the go stack")
+	assert.Empty(t, ConditionBacktrace(err), "it shouldn't have a backtrace")
+
+	err = AddFrame(err, Frame{Procedure: "inner", Source: "lib.scm", Line: 3, Column: 5})
+	err = AddFrame(err, Frame{Source: "main.scm", Line: 10, Column: 1})
+	err = AddFrame(err, Frame{Procedure: "outer"})
+
+	frames := ConditionBacktrace(err)
+	assert.Equal(t, 3, len(frames), "they should be equal")
+	assert.Equal(t, "inner", frames[0].Procedure, "they should be equal")
+	assert.Equal(t, "inner (lib.scm:3:5)", frames[0].String(), "they should be equal")
+	assert.Equal(t, "<anonymous> (main.scm:10:1)", frames[1].String(), "they should be equal")
+	assert.Equal(t, "outer", frames[2].String(), "they should be equal")
+	assert.Equal(t, "  0: inner (lib.scm:3:5)\n  1: <anonymous> (main.scm:10:1)\n  2: outer", FormatBacktrace(err), "they should be equal")
+
+	first := AddFrame(err, Frame{Procedure: "first"})
+	second := AddFrame(err, Frame{Procedure: "second"})
+	assert.Equal(t, 3, len(ConditionBacktrace(err)), "it shouldn't change the given error")
+	assert.Equal(t, "first", ConditionBacktrace(first)[3].Procedure, "they should be equal")
+	assert.Equal(t, "second", ConditionBacktrace(second)[3].Procedure, "they should be equal")
+
+	wrapped := AddFrame(os.ErrNotExist, Frame{Procedure: "open"})
+	assert.True(t, wrapped.(*InterpreterError).Cause == os.ErrNotExist, "it should wrap the go error")
+	assert.Equal(t, 1, len(ConditionBacktrace(wrapped)), "they should be equal")
+
+	assert.Nil(t, AddFrame(nil, Frame{}), "it should be nil")
+	assert.Nil(t, ConditionBacktrace(os.ErrNotExist), "it should be nil")
+	assert.Equal(t, "", FormatBacktrace(os.ErrNotExist), "they should be equal")
+
+	Debug = true
+	defer func() {
+		Debug = false
+	}()
+	err = NewError(ValueError, "bad value")
+	assert.Contains(t, string(err.(*InterpreterError).Stack), "TestBacktrace", "it should capture the go stack")
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// Channel is the type of channel values
+type Channel struct {
+	Capacity int
+	ch       chan Object
+}
+
+// MakeChannel constructs a Channel reference, a zero capacity makes it unbuffered
+func MakeChannel(capacity int) (*Channel, error) {
+	if capacity < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a capacity < 0", "capacity:", capacity)
+	}
+	return &Channel{
+		Capacity: capacity,
+		ch:       make(chan Object, capacity),
+	}, nil
+}
+
+// closedChannelError recovers from the panic of using a closed channel and turns it into an error
+func closedChannelError(err *error, c *Channel) {
+	if r := recover(); r != nil {
+		*err = errors.NewError(errors.ChannelError, "given a closed channel", "c:", c)
+	}
+}
+
+// ChannelSend waits until a channel takes an object
+func ChannelSend(c *Channel, x Object) error {
+	return ChannelSendContext(context.Background(), c, x)
+}
+
+// ChannelSendContext is ChannelSend giving up with an InterruptError once a context finishes
+func ChannelSendContext(ctx context.Context, c *Channel, x Object) (err error) {
+	if c == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "c:", c)
+	}
+	defer closedChannelError(&err, c)
+	select {
+	case c.ch <- x:
+		return nil
+	default:
+	}
+	if err := errors.CheckInterrupt(ctx); err != nil {
+		return err
+	}
+	select {
+	case c.ch <- x:
+		return nil
+	case <-interrupted(ctx):
+		return errors.CheckInterrupt(ctx)
+	}
+}
+
+// ChannelReceive waits for an object of a channel, it reports false once the channel is closed and empty
+func ChannelReceive(c *Channel) (Object, bool, error) {
+	return ChannelReceiveContext(context.Background(), c)
+}
+
+// ChannelReceiveContext is ChannelReceive giving up with an InterruptError once a context finishes
+func ChannelReceiveContext(ctx context.Context, c *Channel) (Object, bool, error) {
+	if c == nil {
+		return nil, false, errors.NewError(errors.NilError, "given a nil reference", "c:", c)
+	}
+	var x Object
+	ok := false
+	select {
+	case x, ok = <-c.ch:
+	default:
+		if err := errors.CheckInterrupt(ctx); err != nil {
+			return nil, false, err
+		}
+		select {
+		case x, ok = <-c.ch:
+		case <-interrupted(ctx):
+			return nil, false, errors.CheckInterrupt(ctx)
+		}
+	}
+	if !ok {
+		return EOF(), false, nil
+	}
+	return x, true, nil
+}
+
+// ChannelClose closes a channel, waiting receivers get the objects left and then the eof object
+func ChannelClose(c *Channel) (err error) {
+	if c == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "c:", c)
+	}
+	defer closedChannelError(&err, c)
+	close(c.ch)
+	return nil
+}
+
+// ChannelCase is an operation on a channel given to Select, it sends Value when Send is set
+// and receives otherwise
+type ChannelCase struct {
+	Channel *Channel
+	Send    bool
+	Value   Object
+}
+
+// Select waits until one of the operations can proceed and performs it, a negative timeout waits
+// forever. It returns the index of the chosen operation, or -1 when the timeout passes first, and
+// for receives the object and whether the channel was still open
+func Select(cases []ChannelCase, timeout time.Duration) (int, Object, bool, error) {
+	return SelectContext(context.Background(), cases, timeout)
+}
+
+// SelectContext is Select giving up with an InterruptError once a context finishes
+func SelectContext(ctx context.Context, cases []ChannelCase, timeout time.Duration) (index int, x Object, ok bool, err error) {
+	selected := make([]reflect.SelectCase, len(cases), len(cases)+2)
+	for i, c := range cases {
+		if c.Channel == nil {
+			return -1, nil, false, errors.NewError(errors.NilError, "given a nil reference", "c:", c.Channel)
+		}
+		if c.Send {
+			value := reflect.New(reflect.TypeOf((*Object)(nil)).Elem()).Elem()
+			if c.Value != nil {
+				value.Set(reflect.ValueOf(c.Value))
+			}
+			selected[i] = reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: reflect.ValueOf(c.Channel.ch),
+				Send: value,
+			}
+		} else {
+			selected[i] = reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(c.Channel.ch),
+			}
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			index, x, ok = -1, nil, false
+			err = errors.NewError(errors.ChannelError, "given a closed channel", "cases:", len(cases))
+		}
+	}()
+	chosen, value, received := reflect.Select(append(selected, reflect.SelectCase{
+		Dir: reflect.SelectDefault,
+	}))
+	if chosen == len(cases) {
+		if err := errors.CheckInterrupt(ctx); err != nil {
+			return -1, nil, false, err
+		}
+		timer, expired := expiration(timeout)
+		if timer != nil {
+			defer timer.Stop()
+		}
+		chosen, value, received = reflect.Select(append(selected, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(expired),
+		}, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(interrupted(ctx)),
+		}))
+		if chosen == len(cases)+1 {
+			return -1, nil, false, errors.CheckInterrupt(ctx)
+		}
+	}
+	if chosen == len(cases) {
+		return -1, nil, false, nil
+	}
+	if cases[chosen].Send {
+		return chosen, nil, true, nil
+	}
+	if !received {
+		return chosen, EOF(), false, nil
+	}
+	return chosen, value.Interface(), true, nil
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eduardoacuna/scheme/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannel(t *testing.T) {
+	c, err := MakeChannel(2)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, reflect.TypeOf(c).Size() <= 8, "byte width should be at most a word")
+
+	err = ChannelSend(c, NewFixnum(1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = ChannelSend(c, Null())
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = ChannelClose(c)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	x, ok, err := ChannelReceive(c)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should receive")
+	assert.Equal(t, NewFixnum(1), x, "they should be equal")
+	x, ok, err = ChannelReceive(c)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should receive")
+	assert.Equal(t, Null(), x, "they should be equal")
+	x, ok, err = ChannelReceive(c)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, ok, "it should be closed")
+	assert.Equal(t, EOF(), x, "they should be equal")
+
+	err = ChannelSend(c, NewFixnum(1))
+	assert.Error(t, err, "it should be an error")
+	err = ChannelClose(c)
+	assert.Error(t, err, "it should be an error")
+
+	unbuffered, _ := MakeChannel(0)
+	sender, _ := MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+		return nil, ChannelSend(unbuffered, GetSymbol("hi"))
+	}, "sender")
+	ThreadStart(sender)
+	x, ok, err = ChannelReceive(unbuffered)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should receive")
+	assert.Equal(t, GetSymbol("hi"), x, "they should be equal")
+	_, _, err = ThreadJoin(sender, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	_, err = MakeChannel(-1)
+	assert.Error(t, err, "it should be an error")
+	err = ChannelSend(nil, Null())
+	assert.Error(t, err, "it should be an error")
+	_, _, err = ChannelReceive(nil)
+	assert.Error(t, err, "it should be an error")
+	err = ChannelClose(nil)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestSelect(t *testing.T) {
+	in, _ := MakeChannel(1)
+	out, _ := MakeChannel(0)
+
+	index, _, _, err := Select([]ChannelCase{{Channel: in}, {Channel: out}}, time.Millisecond)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, -1, index, "it should time out")
+
+	ChannelSend(in, NewFixnum(5))
+	index, x, ok, err := Select([]ChannelCase{{Channel: out}, {Channel: in}}, 0)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, 1, index, "they should be equal")
+	assert.True(t, ok, "it should receive")
+	assert.Equal(t, NewFixnum(5), x, "they should be equal")
+
+	index, _, ok, err = Select([]ChannelCase{{Channel: out, Send: true, Value: NewFixnum(1)}, {Channel: in, Send: true, Value: NewFixnum(2)}}, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, 1, index, "they should be equal")
+	assert.True(t, ok, "it should send")
+	x, _, _ = ChannelReceive(in)
+	assert.Equal(t, NewFixnum(2), x, "they should be equal")
+
+	ChannelSend(in, nil)
+	index, x, ok, err = Select([]ChannelCase{{Channel: in}}, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, 0, index, "they should be equal")
+	assert.True(t, ok, "it should receive")
+	assert.Nil(t, x, "it should be nil")
+
+	ChannelClose(in)
+	index, x, ok, err = Select([]ChannelCase{{Channel: in}}, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, 0, index, "they should be equal")
+	assert.False(t, ok, "it should be closed")
+	assert.Equal(t, EOF(), x, "they should be equal")
+
+	_, _, _, err = Select([]ChannelCase{{Channel: in, Send: true, Value: NewFixnum(1)}}, -1)
+	assert.Error(t, err, "it should be an error")
+	_, _, _, err = Select([]ChannelCase{{Channel: nil}}, -1)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestChannelInterrupt(t *testing.T) {
+	isInterrupt := func(err error) bool {
+		ierr, ok := err.(*errors.InterpreterError)
+		return ok && ierr.Name == errors.InterruptError
+	}
+	c, _ := MakeChannel(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, ok, err := ChannelReceiveContext(ctx, c)
+	assert.False(t, ok, "it shouldn't receive")
+	assert.True(t, isInterrupt(err), "it should be an interrupt error")
+
+	err = ChannelSendContext(ctx, c, NewFixnum(1))
+	assert.True(t, isInterrupt(err), "it should be an interrupt error")
+
+	index, _, _, err := SelectContext(ctx, []ChannelCase{{Channel: c}}, -1)
+	assert.Equal(t, -1, index, "they should be equal")
+	assert.True(t, isInterrupt(err), "it should be an interrupt error")
+
+	buffered, _ := MakeChannel(1)
+	err = ChannelSendContext(context.Background(), buffered, NewFixnum(1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	x, ok, err := ChannelReceiveContext(ctx, buffered)
+	assert.NoError(t, err, "a ready channel shouldn't be interrupted")
+	assert.True(t, ok, "it should receive")
+	assert.Equal(t, NewFixnum(1), x, "they should be equal")
+}
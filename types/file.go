@@ -0,0 +1,218 @@
+package types
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// fileName returns the go string naming a file
+func fileName(name *String) (string, error) {
+	if name == nil {
+		return "", errors.NewError(errors.NilError, "given a nil reference", "name:", name)
+	}
+	return stringToGo(name), nil
+}
+
+// openFile opens a file for reading
+func openFile(name *String) (*os.File, error) {
+	path, err := fileName(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WrapError(errors.FileError, "couldn't open the file for input", err, "name:", path)
+	}
+	return file, nil
+}
+
+// createFile opens a file for writing, truncating it if it exists
+func createFile(name *String) (*os.File, error) {
+	path, err := fileName(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.WrapError(errors.FileError, "couldn't open the file for output", err, "name:", path)
+	}
+	return file, nil
+}
+
+// OpenInputFile constructs a textual InputPort reference reading a file
+func OpenInputFile(name *String) (*InputPort, error) {
+	file, err := openFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewInputPort(file), nil
+}
+
+// OpenTranscodedInputFile constructs a textual InputPort reference decoding a file with an encoding
+func OpenTranscodedInputFile(name *String, encoding Encoding) (*InputPort, error) {
+	if encoding < UTF8Encoding || encoding > Latin1Encoding {
+		return nil, errors.NewError(errors.ValueError, "given an unknown encoding", "encoding:", encoding)
+	}
+	file, err := openFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewTranscodedInputPort(file, encoding)
+}
+
+// OpenBinaryInputFile constructs a binary InputPort reference reading a file
+func OpenBinaryInputFile(name *String) (*InputPort, error) {
+	file, err := openFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewBinaryInputPort(file), nil
+}
+
+// OpenOutputFile constructs a textual OutputPort reference writing a file
+func OpenOutputFile(name *String) (*OutputPort, error) {
+	file, err := createFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewOutputPort(file), nil
+}
+
+// OpenBinaryOutputFile constructs a binary OutputPort reference writing a file
+func OpenBinaryOutputFile(name *String) (*OutputPort, error) {
+	file, err := createFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewBinaryOutputPort(file), nil
+}
+
+// CallWithInputFile calls a procedure with a textual port reading a file and closes it afterwards
+func CallWithInputFile(name *String, proc func(*InputPort) (Object, error)) (Object, error) {
+	port, err := OpenInputFile(name)
+	if err != nil {
+		return nil, err
+	}
+	x, err := proc(port)
+	cerr := CloseInputPort(port)
+	if err != nil {
+		return nil, err
+	}
+	if cerr != nil {
+		return nil, cerr
+	}
+	return x, nil
+}
+
+// WithOutputToFile runs a thunk with the current output port bound to a port writing a file
+func WithOutputToFile(state *DynamicState, name *String, thunk func() (Object, error)) (Object, error) {
+	port, err := OpenOutputFile(name)
+	if err != nil {
+		return nil, err
+	}
+	x, err := Parameterize(state, []*Parameter{currentOutputPort}, []Object{port}, thunk)
+	cerr := CloseOutputPort(port)
+	if err != nil {
+		return nil, err
+	}
+	if cerr != nil {
+		return nil, cerr
+	}
+	return x, nil
+}
+
+// FileExists reports whether a file exists
+func FileExists(name *String) (bool, error) {
+	path, err := fileName(name)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WrapError(errors.FileError, "couldn't inspect the file", err, "name:", path)
+	}
+	return true, nil
+}
+
+// DeleteFile removes a file
+func DeleteFile(name *String) error {
+	path, err := fileName(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil {
+		return errors.WrapError(errors.FileError, "couldn't delete the file", err, "name:", path)
+	}
+	return nil
+}
+
+// DirectoryList returns the list of names of the entries of a directory
+func DirectoryList(name *String) (Object, error) {
+	path, err := fileName(name)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, errors.WrapError(errors.FileError, "couldn't list the directory", err, "name:", path)
+	}
+	elms := make([]Object, len(infos))
+	for i, info := range infos {
+		elms[i] = newStringFromGo(info.Name())
+	}
+	return newList(elms), nil
+}
+
+// CreateDirectory makes a new directory
+func CreateDirectory(name *String) error {
+	path, err := fileName(name)
+	if err != nil {
+		return err
+	}
+	err = os.Mkdir(path, 0777)
+	if err != nil {
+		return errors.WrapError(errors.FileError, "couldn't create the directory", err, "name:", path)
+	}
+	return nil
+}
+
+// RenameFile moves a file to a new name
+func RenameFile(oldName, newName *String) error {
+	oldPath, err := fileName(oldName)
+	if err != nil {
+		return err
+	}
+	newPath, err := fileName(newName)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(oldPath, newPath)
+	if err != nil {
+		return errors.WrapError(errors.FileError, "couldn't rename the file", err, "old:", oldPath, "new:", newPath)
+	}
+	return nil
+}
+
+// FileInfo returns an association list describing the size, permissions, modification time and kind of a file
+func FileInfo(name *String) (Object, error) {
+	path, err := fileName(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.WrapError(errors.FileError, "couldn't inspect the file", err, "name:", path)
+	}
+	return newList([]Object{
+		&Pair{Car: GetSymbol("size"), Cdr: NewFixnum(info.Size())},
+		&Pair{Car: GetSymbol("mode"), Cdr: NewFixnum(int64(info.Mode().Perm()))},
+		&Pair{Car: GetSymbol("modification-time"), Cdr: NewFixnum(info.ModTime().Unix())},
+		&Pair{Car: GetSymbol("directory?"), Cdr: Boolean(info.IsDir())},
+	}), nil
+}
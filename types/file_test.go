@@ -0,0 +1,111 @@
+package types
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eduardoacuna/scheme/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scheme")
+	assert.NoError(t, err, "it shouldn't be an error")
+	defer os.RemoveAll(dir)
+
+	name := newStringFromGo(filepath.Join(dir, "hello.txt"))
+	other := newStringFromGo(filepath.Join(dir, "other.txt"))
+	missing := newStringFromGo(filepath.Join(dir, "missing.txt"))
+
+	exists, err := FileExists(name)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, exists, "it shouldn't exist")
+
+	_, err = WithOutputToFile(MainDynamicState(), name, func() (Object, error) {
+		return True(), WriteString(CurrentOutputPort(MainDynamicState()), newStringFromGo("héllo\n"), 0, 6)
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	exists, err = FileExists(name)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, exists, "it should exist")
+
+	line, err := CallWithInputFile(name, func(port *InputPort) (Object, error) {
+		return ReadLine(port)
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("héllo"), line, "they should be equal")
+
+	oport, err := OpenBinaryOutputFile(other)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteU8(oport, NewFixnum(42))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = CloseOutputPort(oport)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	latin, err := OpenTranscodedInputFile(other, Latin1Encoding)
+	assert.NoError(t, err, "it shouldn't be an error")
+	c, err := ReadChar(latin)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('*'), c, "they should be equal")
+	err = CloseInputPort(latin)
+	assert.NoError(t, err, "it shouldn't be an error")
+	_, err = OpenTranscodedInputFile(other, Encoding(42))
+	assert.Error(t, err, "it should be an error")
+
+	iport, err := OpenBinaryInputFile(other)
+	assert.NoError(t, err, "it shouldn't be an error")
+	b, err := ReadU8(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(42), b, "they should be equal")
+	err = CloseInputPort(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	info, err := FileInfo(other)
+	assert.NoError(t, err, "it shouldn't be an error")
+	size, _ := Car(info.(*Pair))
+	assert.Equal(t, &Pair{Car: GetSymbol("size"), Cdr: NewFixnum(1)}, size, "they should be equal")
+
+	sub := newStringFromGo(filepath.Join(dir, "sub"))
+	err = CreateDirectory(sub)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = CreateDirectory(sub)
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+
+	list, err := DirectoryList(newStringFromGo(dir))
+	assert.NoError(t, err, "it shouldn't be an error")
+	expected := newList([]Object{newStringFromGo("hello.txt"), newStringFromGo("other.txt"), newStringFromGo("sub")})
+	assert.Equal(t, expected, list, "they should be equal")
+
+	err = RenameFile(other, missing)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = RenameFile(other, missing)
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+
+	err = DeleteFile(missing)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = DeleteFile(missing)
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+	_, pathErr := err.(*errors.InterpreterError).Cause.(*os.PathError)
+	assert.True(t, pathErr, "it should wrap the path error")
+
+	_, err = OpenInputFile(missing)
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+	_, err = OpenOutputFile(newStringFromGo(filepath.Join(dir, "nowhere", "x")))
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+	_, err = FileInfo(missing)
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+	_, err = DirectoryList(missing)
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+	_, err = CallWithInputFile(missing, func(port *InputPort) (Object, error) {
+		return nil, nil
+	})
+	assert.True(t, errors.IsFileError(err), "it should be a file error")
+
+	_, err = OpenInputFile(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = FileExists(nil)
+	assert.Error(t, err, "it should be an error")
+}
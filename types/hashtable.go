@@ -0,0 +1,357 @@
+package types
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// HashTable is the type of hash table values
+type HashTable struct {
+	Equivalence func(Object, Object) (bool, error)
+	Hash        func(Object) (uint64, error)
+	buckets     map[uint64][]*hashEntry
+	count       int
+}
+
+// hashEntry is an association of a hash table
+type hashEntry struct {
+	key   Object
+	value Object
+}
+
+// NewHashTable constructs a HashTable reference with custom equivalence and hash procedures,
+// objects that are equivalent must have the same hash
+func NewHashTable(equivalence func(Object, Object) (bool, error), hash func(Object) (uint64, error)) (*HashTable, error) {
+	if equivalence == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "equivalence:", equivalence)
+	}
+	if hash == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "hash:", hash)
+	}
+	return &HashTable{
+		Equivalence: equivalence,
+		Hash:        hash,
+		buckets:     map[uint64][]*hashEntry{},
+	}, nil
+}
+
+// MakeEqHashTable constructs a HashTable reference comparing keys with eq?
+func MakeEqHashTable() *HashTable {
+	return MakeEqvHashTable()
+}
+
+// MakeEqvHashTable constructs a HashTable reference comparing keys with eqv?
+func MakeEqvHashTable() *HashTable {
+	ht, _ := NewHashTable(func(x, y Object) (bool, error) {
+		return Eqv(x, y), nil
+	}, EqvHash)
+	return ht
+}
+
+// MakeEqualHashTable constructs a HashTable reference comparing keys with equal?
+func MakeEqualHashTable() *HashTable {
+	ht, _ := NewHashTable(func(x, y Object) (bool, error) {
+		return Equal(x, y), nil
+	}, func(x Object) (uint64, error) {
+		return EqualHash(x), nil
+	})
+	return ht
+}
+
+// MakeStringHashTable constructs a HashTable reference comparing string keys with string=?
+func MakeStringHashTable() *HashTable {
+	ht, _ := NewHashTable(func(x, y Object) (bool, error) {
+		sx, okx := x.(*String)
+		sy, oky := y.(*String)
+		if !okx || !oky {
+			return false, errors.NewError(errors.TypeError, "given a non string key", "x:", x, "y:", y)
+		}
+		return Equal(sx, sy), nil
+	}, StringHash)
+	return ht
+}
+
+// EqvHash returns a hash of an object that is the same for eqv objects
+func EqvHash(x Object) (uint64, error) {
+	switch x := x.(type) {
+	case Immediate:
+		return uint64(x), nil
+	case Fixnum:
+		return uint64(x), nil
+	case Character:
+		return uint64(x), nil
+	case Flonum:
+		return math.Float64bits(float64(x)), nil
+	}
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Ptr:
+		return uint64(v.Pointer()), nil
+	case reflect.Func:
+		return uint64(funcIdentity(x)), nil
+	}
+	return 0, nil
+}
+
+// StringHash returns a hash of the characters of a string
+func StringHash(x Object) (uint64, error) {
+	str, ok := x.(*String)
+	if !ok {
+		return 0, errors.NewError(errors.TypeError, "given a non string", "x:", x)
+	}
+	if str == nil {
+		return 0, errors.NewError(errors.NilError, "given a nil reference", "str:", str)
+	}
+	budget := 0
+	return equalHash(str, &budget), nil
+}
+
+// EqualHash returns a hash of an object that is the same for equal objects
+func EqualHash(x Object) uint64 {
+	budget := equalHashBudget
+	return equalHash(x, &budget)
+}
+
+const (
+	hashOffset = 14695981039346656037
+	hashPrime  = 1099511628211
+)
+
+// equalHashBudget is the number of pairs and vectors EqualHash looks into,
+// which keeps the hash of large and circular structures cheap
+const equalHashBudget = 64
+
+// hashMix folds a value into a running hash
+func hashMix(h, value uint64) uint64 {
+	return (h ^ value) * hashPrime
+}
+
+// equalHash hashes the contents of an object visiting at most budget pairs and vectors,
+// the visits follow the same order for equal objects so they hash the same
+func equalHash(x Object, budget *int) uint64 {
+	h := uint64(hashOffset)
+	switch x := x.(type) {
+	case *Pair:
+		if x == nil || *budget <= 0 {
+			return h
+		}
+		*budget--
+		h = hashMix(h, equalHash(x.Car, budget))
+		return hashMix(h, equalHash(x.Cdr, budget))
+	case *Vector:
+		if x == nil || *budget <= 0 {
+			return h
+		}
+		*budget--
+		for _, elm := range x.Elements {
+			h = hashMix(h, equalHash(elm, budget))
+		}
+		return h
+	case *String:
+		if x == nil {
+			return h
+		}
+		for _, c := range x.Elements {
+			h = hashMix(h, uint64(c))
+		}
+		return h
+	case *ByteVector:
+		if x == nil {
+			return h
+		}
+		for _, b := range x.Elements {
+			h = hashMix(h, uint64(b))
+		}
+		return h
+	}
+	value, _ := EqvHash(x)
+	return hashMix(h, value)
+}
+
+// lookup finds the entry of a key in a hash table, returning nil when it's absent
+func (ht *HashTable) lookup(key Object) (uint64, *hashEntry, error) {
+	h, err := ht.Hash(key)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, entry := range ht.buckets[h] {
+		same, err := ht.Equivalence(entry.key, key)
+		if err != nil {
+			return 0, nil, err
+		}
+		if same {
+			return h, entry, nil
+		}
+	}
+	return h, nil, nil
+}
+
+// entries returns a snapshot of the entries of a hash table
+func (ht *HashTable) entries() []*hashEntry {
+	entries := make([]*hashEntry, 0, ht.count)
+	for _, bucket := range ht.buckets {
+		entries = append(entries, bucket...)
+	}
+	return entries
+}
+
+// HashTableRef returns the value associated to a key and whether there was one
+func HashTableRef(ht *HashTable, key Object) (Object, bool, error) {
+	if ht == nil {
+		return nil, false, errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	_, entry, err := ht.lookup(key)
+	if err != nil || entry == nil {
+		return nil, false, err
+	}
+	return entry.value, true, nil
+}
+
+// HashTableRefDefault returns the value associated to a key or a default value
+func HashTableRefDefault(ht *HashTable, key Object, def Object) (Object, error) {
+	value, ok, err := HashTableRef(ht, key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return def, nil
+	}
+	return value, nil
+}
+
+// HashTableSet associates a value to a key
+func HashTableSet(ht *HashTable, key Object, value Object) error {
+	if ht == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	h, entry, err := ht.lookup(key)
+	if err != nil {
+		return err
+	}
+	if entry != nil {
+		entry.value = value
+		return nil
+	}
+	ht.buckets[h] = append(ht.buckets[h], &hashEntry{
+		key:   key,
+		value: value,
+	})
+	ht.count++
+	return nil
+}
+
+// HashTableDelete removes the association of a key if there's one
+func HashTableDelete(ht *HashTable, key Object) error {
+	if ht == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	h, entry, err := ht.lookup(key)
+	if err != nil || entry == nil {
+		return err
+	}
+	bucket := ht.buckets[h]
+	for i, e := range bucket {
+		if e == entry {
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			break
+		}
+	}
+	if len(bucket) == 0 {
+		delete(ht.buckets, h)
+	} else {
+		ht.buckets[h] = bucket
+	}
+	ht.count--
+	return nil
+}
+
+// HashTableUpdate associates to a key the result of a procedure on its value,
+// the value comes from fail when the key is absent and fail may be nil
+func HashTableUpdate(ht *HashTable, key Object, proc func(Object) (Object, error), fail func() (Object, error)) error {
+	value, ok, err := HashTableRef(ht, key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if fail == nil {
+			return errors.NewError(errors.ValueError, "given a key without association", "key:", key)
+		}
+		value, err = fail()
+		if err != nil {
+			return err
+		}
+	}
+	value, err = proc(value)
+	if err != nil {
+		return err
+	}
+	return HashTableSet(ht, key, value)
+}
+
+// HashTableWalk calls a procedure with every key and value of a hash table
+func HashTableWalk(ht *HashTable, proc func(Object, Object) error) error {
+	if ht == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	for _, entry := range ht.entries() {
+		if err := proc(entry.key, entry.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HashTableFold combines every key and value of a hash table into an accumulated value
+func HashTableFold(ht *HashTable, kons func(Object, Object, Object) (Object, error), knil Object) (Object, error) {
+	if ht == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	acc := knil
+	for _, entry := range ht.entries() {
+		var err error
+		acc, err = kons(entry.key, entry.value, acc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+// HashTableToAlist returns the associations of a hash table as a list of pairs
+func HashTableToAlist(ht *HashTable) (Object, error) {
+	if ht == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	entries := ht.entries()
+	elms := make([]Object, len(entries))
+	for i, entry := range entries {
+		elms[i] = &Pair{
+			Car: entry.key,
+			Cdr: entry.value,
+		}
+	}
+	return newList(elms), nil
+}
+
+// HashTableKeys returns the list of keys of a hash table
+func HashTableKeys(ht *HashTable) (Object, error) {
+	if ht == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	entries := ht.entries()
+	elms := make([]Object, len(entries))
+	for i, entry := range entries {
+		elms[i] = entry.key
+	}
+	return newList(elms), nil
+}
+
+// HashTableCount returns the number of associations of a hash table
+func HashTableCount(ht *HashTable) (int, error) {
+	if ht == nil {
+		return 0, errors.NewError(errors.NilError, "given a nil reference", "ht:", ht)
+	}
+	return ht.count, nil
+}
@@ -0,0 +1,225 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eduardoacuna/scheme/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashTable(t *testing.T) {
+	ht := MakeEqualHashTable()
+	key1, _ := NewPair(NewFixnum(1), NewCharacter('a'))
+	key2, _ := NewPair(NewFixnum(1), NewCharacter('a'))
+
+	assert.True(t, reflect.TypeOf(ht).Size() <= 8, "byte width should be at most a word")
+
+	err := HashTableSet(ht, key1, GetSymbol("one"))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = HashTableSet(ht, NewFixnum(2), GetSymbol("two"))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = HashTableSet(ht, key2, GetSymbol("uno"))
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	count, err := HashTableCount(ht)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, 2, count, "they should be equal")
+
+	value, ok, err := HashTableRef(ht, key1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should be found")
+	assert.Equal(t, GetSymbol("uno"), value, "they should be equal")
+
+	_, ok, err = HashTableRef(ht, NewFixnum(3))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, ok, "it shouldn't be found")
+
+	value, err = HashTableRefDefault(ht, NewFixnum(3), False())
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, False(), value, "they should be equal")
+	value, err = HashTableRefDefault(ht, NewFixnum(2), False())
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, GetSymbol("two"), value, "they should be equal")
+
+	increment := func(x Object) (Object, error) {
+		return x.(Fixnum) + 1, nil
+	}
+	zero := func() (Object, error) {
+		return NewFixnum(0), nil
+	}
+	err = HashTableUpdate(ht, GetSymbol("n"), increment, zero)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = HashTableUpdate(ht, GetSymbol("n"), increment, nil)
+	assert.NoError(t, err, "it shouldn't be an error")
+	value, err = HashTableRefDefault(ht, GetSymbol("n"), False())
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(2), value, "they should be equal")
+	err = HashTableUpdate(ht, GetSymbol("m"), increment, nil)
+	assert.Error(t, err, "it should be an error")
+
+	walked := 0
+	err = HashTableWalk(ht, func(key, value Object) error {
+		walked++
+		return HashTableDelete(ht, key)
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, 3, walked, "they should be equal")
+	count, _ = HashTableCount(ht)
+	assert.Equal(t, 0, count, "they should be equal")
+
+	err = HashTableDelete(ht, key1)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	_, _, err = HashTableRef(nil, key1)
+	assert.Error(t, err, "it should be an error")
+	err = HashTableSet(nil, key1, key1)
+	assert.Error(t, err, "it should be an error")
+	err = HashTableDelete(nil, key1)
+	assert.Error(t, err, "it should be an error")
+	_, err = HashTableCount(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = NewHashTable(nil, EqvHash)
+	assert.Error(t, err, "it should be an error")
+	_, err = NewHashTable(func(x, y Object) (bool, error) { return true, nil }, nil)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestHashTableEquivalences(t *testing.T) {
+	key1, _ := NewString(2, NewCharacter('k'))
+	key2, _ := NewString(2, NewCharacter('k'))
+
+	eq := MakeEqHashTable()
+	HashTableSet(eq, key1, NewFixnum(1))
+	HashTableSet(eq, NewFlonum(0.5), NewFixnum(2))
+	_, ok, _ := HashTableRef(eq, key2)
+	assert.False(t, ok, "it shouldn't be found")
+	_, ok, _ = HashTableRef(eq, key1)
+	assert.True(t, ok, "it should be found")
+	_, ok, _ = HashTableRef(eq, NewFlonum(0.5))
+	assert.True(t, ok, "it should be found")
+
+	str := MakeStringHashTable()
+	err := HashTableSet(str, key1, NewFixnum(1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	value, ok, err := HashTableRef(str, key2)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should be found")
+	assert.Equal(t, NewFixnum(1), value, "they should be equal")
+	err = HashTableSet(str, NewFixnum(1), NewFixnum(1))
+	assert.Error(t, err, "it should be an error")
+
+	parity, err := NewHashTable(func(x, y Object) (bool, error) {
+		return x.(Fixnum)%2 == y.(Fixnum)%2, nil
+	}, func(x Object) (uint64, error) {
+		return uint64(x.(Fixnum) % 2), nil
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	HashTableSet(parity, NewFixnum(1), GetSymbol("odd"))
+	HashTableSet(parity, NewFixnum(2), GetSymbol("even"))
+	value, _ = HashTableRefDefault(parity, NewFixnum(7), False())
+	assert.Equal(t, GetSymbol("odd"), value, "they should be equal")
+
+	sum, err := HashTableFold(parity, func(key, value, acc Object) (Object, error) {
+		return acc.(Fixnum) + key.(Fixnum), nil
+	}, NewFixnum(0))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(3), sum, "they should be equal")
+
+	keys, err := HashTableKeys(parity)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, Equal(keys, newList([]Object{NewFixnum(1), NewFixnum(2)})) ||
+		Equal(keys, newList([]Object{NewFixnum(2), NewFixnum(1)})), "it should list the keys")
+
+	alist, err := HashTableToAlist(parity)
+	assert.NoError(t, err, "it shouldn't be an error")
+	odd := &Pair{Car: NewFixnum(1), Cdr: GetSymbol("odd")}
+	even := &Pair{Car: NewFixnum(2), Cdr: GetSymbol("even")}
+	assert.True(t, Equal(alist, newList([]Object{odd, even})) ||
+		Equal(alist, newList([]Object{even, odd})), "it should list the associations")
+
+	failing := func(key, value, acc Object) (Object, error) {
+		return nil, errors.NewError(errors.ValueError, "failing on purpose")
+	}
+	_, err = HashTableFold(parity, failing, NewFixnum(0))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestEqvHash(t *testing.T) {
+	point, _ := NewRecordType("point", nil, GetSymbol("x"), GetSymbol("y"))
+	pointX, _ := RecordAccessor(point, GetSymbol("x"))
+	pointY, _ := RecordAccessor(point, GetSymbol("y"))
+
+	hx, err := EqvHash(pointX)
+	assert.NoError(t, err, "it shouldn't be an error")
+	again, _ := EqvHash(pointX)
+	assert.Equal(t, hx, again, "they should be equal")
+
+	ht := MakeEqvHashTable()
+	HashTableSet(ht, pointX, GetSymbol("x"))
+	HashTableSet(ht, pointY, GetSymbol("y"))
+	x, ok, err := HashTableRef(ht, pointX)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should find a procedure key")
+	assert.Equal(t, GetSymbol("x"), x, "they should be equal")
+	x, _, _ = HashTableRef(ht, pointY)
+	assert.Equal(t, GetSymbol("y"), x, "they should be equal")
+	count, _ := HashTableCount(ht)
+	assert.Equal(t, 2, count, "they should be equal")
+}
+
+func TestEqualHash(t *testing.T) {
+	cons1, _ := NewPair(NewFixnum(1), NewCharacter('a'))
+	cons2, _ := NewPair(NewFixnum(1), NewCharacter('a'))
+	vec1, _ := NewVector(3, cons1)
+	vec2, _ := NewVector(3, cons2)
+	str1, _ := NewString(3, NewCharacter('s'))
+	str2, _ := NewString(3, NewCharacter('s'))
+	bv1, _ := NewByteVector(3, NewFixnum(9))
+	bv2, _ := NewByteVector(3, NewFixnum(9))
+
+	assert.Equal(t, EqualHash(cons1), EqualHash(cons2), "they should be equal")
+	assert.Equal(t, EqualHash(vec1), EqualHash(vec2), "they should be equal")
+	assert.Equal(t, EqualHash(str1), EqualHash(str2), "they should be equal")
+	assert.Equal(t, EqualHash(bv1), EqualHash(bv2), "they should be equal")
+	assert.NotEqual(t, EqualHash(str1), EqualHash(bv1), "they shouldn't be equal")
+
+	circular1, _ := NewPair(NewFixnum(1), Null())
+	circular1.Cdr = circular1
+	circular2, _ := NewPair(NewFixnum(1), Null())
+	circular2.Cdr = circular2
+	unrolled, _ := NewPair(NewFixnum(1), circular2)
+	assert.Equal(t, EqualHash(circular1), EqualHash(unrolled), "they should be equal")
+
+	ht := MakeEqualHashTable()
+	HashTableSet(ht, circular1, GetSymbol("found"))
+	x, ok, err := HashTableRef(ht, unrolled)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should find a circular key")
+	assert.Equal(t, GetSymbol("found"), x, "they should be equal")
+
+	wide, _ := NewVector(8, Null())
+	for i := range wide.Elements {
+		wide.Elements[i] = wide
+	}
+	done := make(chan uint64)
+	go func() {
+		done <- EqualHash(wide)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("it should hash a self referencing vector quickly")
+	}
+
+	h1, err := StringHash(str1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	h2, err := StringHash(str2)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, h1, h2, "they should be equal")
+	_, err = StringHash(NewFixnum(1))
+	assert.Error(t, err, "it should be an error")
+	_, err = StringHash((*String)(nil))
+	assert.Error(t, err, "it should be an error")
+}
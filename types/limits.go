@@ -0,0 +1,86 @@
+package types
+
+import (
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// Limits is the type of the resource budget of an evaluation, a zero maximum means no limit
+type Limits struct {
+	MaxAllocations int64
+	MaxCallDepth   int
+	MaxSteps       int64
+	allocations    int64
+	callDepth      int
+	steps          int64
+}
+
+// NewLimits constructs a Limits reference
+func NewLimits(maxAllocations int64, maxCallDepth int, maxSteps int64) (*Limits, error) {
+	if maxAllocations < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a maximum < 0", "maxAllocations:", maxAllocations)
+	}
+	if maxCallDepth < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a maximum < 0", "maxCallDepth:", maxCallDepth)
+	}
+	if maxSteps < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a maximum < 0", "maxSteps:", maxSteps)
+	}
+	return &Limits{
+		MaxAllocations: maxAllocations,
+		MaxCallDepth:   maxCallDepth,
+		MaxSteps:       maxSteps,
+	}, nil
+}
+
+// Allocate accounts for n allocated objects or bytes
+func (limits *Limits) Allocate(n int64) error {
+	if limits == nil {
+		return nil
+	}
+	limits.allocations += n
+	if limits.MaxAllocations > 0 && limits.allocations > limits.MaxAllocations {
+		return errors.NewError(errors.AllocationLimitError, "exceeded the allocation limit", "max:", limits.MaxAllocations)
+	}
+	return nil
+}
+
+// Enter accounts for a procedure call starting
+func (limits *Limits) Enter() error {
+	if limits == nil {
+		return nil
+	}
+	limits.callDepth++
+	if limits.MaxCallDepth > 0 && limits.callDepth > limits.MaxCallDepth {
+		limits.callDepth--
+		return errors.NewError(errors.CallDepthLimitError, "exceeded the call depth limit", "max:", limits.MaxCallDepth)
+	}
+	return nil
+}
+
+// Leave accounts for a procedure call returning
+func (limits *Limits) Leave() {
+	if limits != nil && limits.callDepth > 0 {
+		limits.callDepth--
+	}
+}
+
+// Step accounts for an evaluation step
+func (limits *Limits) Step() error {
+	if limits == nil {
+		return nil
+	}
+	limits.steps++
+	if limits.MaxSteps > 0 && limits.steps > limits.MaxSteps {
+		return errors.NewError(errors.StepLimitError, "exceeded the step limit", "max:", limits.MaxSteps)
+	}
+	return nil
+}
+
+// Reset forgets the resources used so far
+func (limits *Limits) Reset() {
+	if limits != nil {
+		limits.allocations = 0
+		limits.callDepth = 0
+		limits.steps = 0
+	}
+}
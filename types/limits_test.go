@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/eduardoacuna/scheme/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits(t *testing.T) {
+	limits, err := NewLimits(10, 2, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	assert.NoError(t, limits.Allocate(4), "it shouldn't be an error")
+	assert.NoError(t, limits.Allocate(6), "it shouldn't be an error")
+	err = limits.Allocate(1)
+	assert.Error(t, err, "it should be an error")
+	assert.Equal(t, errors.ErrorName(errors.AllocationLimitError), err.(*errors.InterpreterError).Name, "they should be equal")
+
+	assert.NoError(t, limits.Enter(), "it shouldn't be an error")
+	assert.NoError(t, limits.Enter(), "it shouldn't be an error")
+	err = limits.Enter()
+	assert.Error(t, err, "it should be an error")
+	assert.Equal(t, errors.ErrorName(errors.CallDepthLimitError), err.(*errors.InterpreterError).Name, "they should be equal")
+	limits.Leave()
+	assert.NoError(t, limits.Enter(), "it shouldn't be an error")
+
+	assert.NoError(t, limits.Step(), "it shouldn't be an error")
+	assert.NoError(t, limits.Step(), "it shouldn't be an error")
+	assert.NoError(t, limits.Step(), "it shouldn't be an error")
+	err = limits.Step()
+	assert.Error(t, err, "it should be an error")
+	assert.Equal(t, errors.ErrorName(errors.StepLimitError), err.(*errors.InterpreterError).Name, "they should be equal")
+
+	limits.Reset()
+	assert.NoError(t, limits.Allocate(10), "it shouldn't be an error")
+	assert.NoError(t, limits.Step(), "it shouldn't be an error")
+
+	unlimited, err := NewLimits(0, 0, 0)
+	assert.NoError(t, err, "it shouldn't be an error")
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, unlimited.Enter(), "it shouldn't be an error")
+	}
+	var none *Limits
+	assert.NoError(t, none.Allocate(1), "it shouldn't be an error")
+	assert.NoError(t, none.Enter(), "it shouldn't be an error")
+	assert.NoError(t, none.Step(), "it shouldn't be an error")
+	none.Leave()
+	none.Reset()
+
+	_, err = NewLimits(-1, 0, 0)
+	assert.Error(t, err, "it should be an error")
+	_, err = NewLimits(0, -1, 0)
+	assert.Error(t, err, "it should be an error")
+	_, err = NewLimits(0, 0, -1)
+	assert.Error(t, err, "it should be an error")
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// Parameter is the type of parameter objects, Value is the global value and the bindings
+// made by Parameterize live in the dynamic environment of each thread
+type Parameter struct {
+	Value     Object
+	Converter func(Object) (Object, error)
+}
+
+// MakeParameter constructs a Parameter reference, the converter may be nil and is applied to
+// the initial value and to every value given through Parameterize
+func MakeParameter(value Object, converter func(Object) (Object, error)) (*Parameter, error) {
+	if converter != nil {
+		var err error
+		value, err = converter(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Parameter{
+		Value:     value,
+		Converter: converter,
+	}, nil
+}
+
+// dynamicFrame is a binding of a parameter, frames are never modified so threads
+// can start from the bindings of the thread that made them
+type dynamicFrame struct {
+	param *Parameter
+	value Object
+	next  *dynamicFrame
+}
+
+// DynamicState is the type of the dynamic environments of threads, it holds the parameter bindings
+// made by Parameterize and only the thread owning it may use it
+type DynamicState struct {
+	env *dynamicFrame
+}
+
+var mainState = &DynamicState{}
+
+// MainDynamicState returns the dynamic environment of the main thread
+func MainDynamicState() *DynamicState {
+	return mainState
+}
+
+// lookup returns the innermost binding of a parameter or its global value
+func (state *DynamicState) lookup(param *Parameter) Object {
+	if state != nil {
+		for frame := state.env; frame != nil; frame = frame.next {
+			if frame.param == param {
+				return frame.value
+			}
+		}
+	}
+	return param.Value
+}
+
+// ParameterValue returns the value of a parameter in a dynamic environment
+func ParameterValue(state *DynamicState, param *Parameter) (Object, error) {
+	if state == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "state:", state)
+	}
+	if param == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "param:", param)
+	}
+	return state.lookup(param), nil
+}
+
+// Parameterize runs a body with parameters bound to new values in a dynamic environment,
+// restoring the previous bindings however the body returns
+func Parameterize(state *DynamicState, params []*Parameter, values []Object, body func() (Object, error)) (Object, error) {
+	if state == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "state:", state)
+	}
+	if body == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "body:", body)
+	}
+	if len(params) != len(values) {
+		return nil, errors.NewError(errors.ValueError, "given a different number of parameters and values", "params:", len(params), "values:", len(values))
+	}
+	converted := make([]Object, len(values))
+	for i, param := range params {
+		if param == nil {
+			return nil, errors.NewError(errors.NilError, "given a nil reference", "param:", param)
+		}
+		converted[i] = values[i]
+		if param.Converter != nil {
+			var err error
+			converted[i], err = param.Converter(values[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	saved := state.env
+	for i, param := range params {
+		state.env = &dynamicFrame{
+			param: param,
+			value: converted[i],
+			next:  state.env,
+		}
+	}
+	defer func() {
+		state.env = saved
+	}()
+	return body()
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eduardoacuna/scheme/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameter(t *testing.T) {
+	toFixnum := func(x Object) (Object, error) {
+		switch x := x.(type) {
+		case Fixnum:
+			return x, nil
+		case Flonum:
+			return NewFixnum(int64(x)), nil
+		}
+		return nil, errors.NewError(errors.TypeError, "given a non number", "x:", x)
+	}
+
+	state := &DynamicState{}
+	param, err := MakeParameter(NewFlonum(10.5), toFixnum)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, reflect.TypeOf(param).Size() <= 8, "byte width should be at most a word")
+
+	value, err := ParameterValue(state, param)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(10), value, "the converter should apply to the initial value")
+
+	plain, err := MakeParameter(GetSymbol("a"), nil)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	x, err := Parameterize(state, []*Parameter{param, plain}, []Object{NewFlonum(2.5), GetSymbol("b")}, func() (Object, error) {
+		v1, _ := ParameterValue(state, param)
+		v2, _ := ParameterValue(state, plain)
+		return &Pair{Car: v1, Cdr: v2}, nil
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, &Pair{Car: NewFixnum(2), Cdr: GetSymbol("b")}, x, "they should be equal")
+
+	value, _ = ParameterValue(state, param)
+	assert.Equal(t, NewFixnum(10), value, "it should restore the value")
+	value, _ = ParameterValue(state, plain)
+	assert.Equal(t, GetSymbol("a"), value, "it should restore the value")
+
+	_, err = Parameterize(state, []*Parameter{param}, []Object{NewFixnum(3)}, func() (Object, error) {
+		return nil, errors.NewError(errors.ValueError, "failing on purpose")
+	})
+	assert.Error(t, err, "it should be an error")
+	value, _ = ParameterValue(state, param)
+	assert.Equal(t, NewFixnum(10), value, "it should restore the value")
+
+	func() {
+		defer func() {
+			recover()
+		}()
+		Parameterize(state, []*Parameter{param}, []Object{NewFixnum(3)}, func() (Object, error) {
+			panic("escaping")
+		})
+	}()
+	value, _ = ParameterValue(state, param)
+	assert.Equal(t, NewFixnum(10), value, "it should restore the value")
+
+	x, err = Parameterize(state, []*Parameter{plain}, []Object{GetSymbol("b")}, func() (Object, error) {
+		return ParameterValue(&DynamicState{}, plain)
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, GetSymbol("a"), x, "another dynamic environment shouldn't see the binding")
+
+	nothing := func() (Object, error) {
+		return nil, nil
+	}
+	_, err = Parameterize(state, []*Parameter{param, plain}, []Object{NewFixnum(1), GetSymbol("x")}, nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = Parameterize(state, []*Parameter{param, plain}, []Object{GetSymbol("bad"), GetSymbol("x")}, nothing)
+	assert.Error(t, err, "it should be an error")
+	value, _ = ParameterValue(state, plain)
+	assert.Equal(t, GetSymbol("a"), value, "a failing conversion shouldn't bind anything")
+
+	_, err = Parameterize(state, []*Parameter{param}, []Object{}, nothing)
+	assert.Error(t, err, "it should be an error")
+	_, err = Parameterize(state, []*Parameter{nil}, []Object{Null()}, nothing)
+	assert.Error(t, err, "it should be an error")
+	_, err = MakeParameter(GetSymbol("bad"), toFixnum)
+	assert.Error(t, err, "it should be an error")
+	_, err = ParameterValue(state, nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = ParameterValue(nil, param)
+	assert.Error(t, err, "it should be an error")
+	_, err = Parameterize(nil, []*Parameter{param}, []Object{NewFixnum(1)}, nothing)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestPortParameters(t *testing.T) {
+	state := &DynamicState{}
+	port := OpenOutputString()
+	x, err := Parameterize(state, []*Parameter{CurrentOutputPortParameter()}, []Object{port}, func() (Object, error) {
+		return CurrentOutputPort(state), nil
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, x == port, "they should be the same")
+	assert.False(t, CurrentOutputPort(state) == port, "it should restore the port")
+
+	nothing := func() (Object, error) {
+		return nil, nil
+	}
+	_, err = Parameterize(state, []*Parameter{CurrentInputPortParameter()}, []Object{port}, nothing)
+	assert.Error(t, err, "it should be an error")
+	_, err = Parameterize(state, []*Parameter{CurrentErrorPortParameter()}, []Object{NewFixnum(1)}, nothing)
+	assert.Error(t, err, "it should be an error")
+}
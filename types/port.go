@@ -0,0 +1,878 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// Encoding is the type of the character encodings of textual input ports
+type Encoding int
+
+const (
+	// UTF8Encoding decodes characters from UTF-8
+	UTF8Encoding Encoding = iota
+	// UTF16BEEncoding decodes characters from big endian UTF-16
+	UTF16BEEncoding
+	// UTF16LEEncoding decodes characters from little endian UTF-16
+	UTF16LEEncoding
+	// Latin1Encoding decodes characters from ISO-8859-1
+	Latin1Encoding
+)
+
+// Buffering is the type of the buffering modes of output ports
+type Buffering int
+
+const (
+	// NoBuffering writes data to the writer as soon as it's written to the port
+	NoBuffering Buffering = iota
+	// LineBuffering writes data to the writer when a line is completed
+	LineBuffering
+	// BlockBuffering writes data to the writer when the buffer is full
+	BlockBuffering
+)
+
+// InputPort is the type of input port values
+type InputPort struct {
+	Reader   io.Reader
+	Binary   bool
+	Closed   bool
+	Encoding Encoding
+	buffer   *bufio.Reader
+}
+
+// NewInputPort constructs a textual InputPort reference
+func NewInputPort(reader io.Reader) *InputPort {
+	return &InputPort{
+		Reader: reader,
+		buffer: bufio.NewReader(reader),
+	}
+}
+
+// NewTranscodedInputPort constructs a textual InputPort reference decoding characters with an encoding
+func NewTranscodedInputPort(reader io.Reader, encoding Encoding) (*InputPort, error) {
+	if encoding < UTF8Encoding || encoding > Latin1Encoding {
+		return nil, errors.NewError(errors.ValueError, "given an unknown encoding", "encoding:", encoding)
+	}
+	port := NewInputPort(reader)
+	port.Encoding = encoding
+	return port, nil
+}
+
+// NewBinaryInputPort constructs a binary InputPort reference
+func NewBinaryInputPort(reader io.Reader) *InputPort {
+	port := NewInputPort(reader)
+	port.Binary = true
+	return port
+}
+
+// OutputPort is the type of output port values
+type OutputPort struct {
+	Writer    io.Writer
+	Binary    bool
+	Closed    bool
+	Buffering Buffering
+	buffer    *bufio.Writer
+}
+
+// NewOutputPort constructs a textual OutputPort reference
+func NewOutputPort(writer io.Writer) *OutputPort {
+	return &OutputPort{
+		Writer: writer,
+	}
+}
+
+// NewBinaryOutputPort constructs a binary OutputPort reference
+func NewBinaryOutputPort(writer io.Writer) *OutputPort {
+	port := NewOutputPort(writer)
+	port.Binary = true
+	return port
+}
+
+// OpenInputString constructs a textual InputPort reference reading the characters of a string
+func OpenInputString(str *String) (*InputPort, error) {
+	if str == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "str:", str)
+	}
+	return NewInputPort(strings.NewReader(stringToGo(str))), nil
+}
+
+// OpenOutputString constructs a textual OutputPort reference accumulating characters in memory
+func OpenOutputString() *OutputPort {
+	return NewOutputPort(bytes.NewBuffer(nil))
+}
+
+// GetOutputString returns the characters written so far to a port made by OpenOutputString
+func GetOutputString(port *OutputPort) (*String, error) {
+	if port == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	buff, ok := port.Writer.(*bytes.Buffer)
+	if !ok || port.Binary {
+		return nil, errors.NewError(errors.TypeError, "given a non string port", "port:", port)
+	}
+	if err := flushBuffer(port); err != nil {
+		return nil, err
+	}
+	return newStringFromGo(buff.String()), nil
+}
+
+// OpenInputByteVector constructs a binary InputPort reference reading a copy of the bytes of a byte-vector
+func OpenInputByteVector(bv *ByteVector) (*InputPort, error) {
+	if bv == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "bv:", bv)
+	}
+	data := make([]byte, len(bv.Elements))
+	copy(data, bv.Elements)
+	return NewBinaryInputPort(bytes.NewReader(data)), nil
+}
+
+// OpenOutputByteVector constructs a binary OutputPort reference accumulating bytes in memory
+func OpenOutputByteVector() *OutputPort {
+	return NewBinaryOutputPort(bytes.NewBuffer(nil))
+}
+
+// GetOutputByteVector returns the bytes written so far to a port made by OpenOutputByteVector
+func GetOutputByteVector(port *OutputPort) (*ByteVector, error) {
+	if port == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	buff, ok := port.Writer.(*bytes.Buffer)
+	if !ok || !port.Binary {
+		return nil, errors.NewError(errors.TypeError, "given a non byte-vector port", "port:", port)
+	}
+	if err := flushBuffer(port); err != nil {
+		return nil, err
+	}
+	data := make([]byte, buff.Len())
+	copy(data, buff.Bytes())
+	return &ByteVector{
+		Elements: data,
+		Length:   len(data),
+	}, nil
+}
+
+// WithOutputToString runs a thunk with the current output port bound to a fresh string port
+// and returns what it wrote, the value of the thunk is discarded
+func WithOutputToString(state *DynamicState, thunk func() (Object, error)) (*String, error) {
+	port := OpenOutputString()
+	_, err := Parameterize(state, []*Parameter{currentOutputPort}, []Object{port}, thunk)
+	if err != nil {
+		return nil, err
+	}
+	return GetOutputString(port)
+}
+
+// inputPortConverter makes sure a parameter only holds input ports
+func inputPortConverter(x Object) (Object, error) {
+	port, ok := x.(*InputPort)
+	if !ok || port == nil {
+		return nil, errors.NewError(errors.TypeError, "given a non input port", "x:", x)
+	}
+	return port, nil
+}
+
+// outputPortConverter makes sure a parameter only holds output ports
+func outputPortConverter(x Object) (Object, error) {
+	port, ok := x.(*OutputPort)
+	if !ok || port == nil {
+		return nil, errors.NewError(errors.TypeError, "given a non output port", "x:", x)
+	}
+	return port, nil
+}
+
+var (
+	currentInputPort, _  = MakeParameter(NewInputPort(os.Stdin), inputPortConverter)
+	currentOutputPort, _ = MakeParameter(NewOutputPort(os.Stdout), outputPortConverter)
+	currentErrorPort, _  = MakeParameter(NewOutputPort(os.Stderr), outputPortConverter)
+)
+
+// CurrentInputPortParameter returns the parameter holding the default port of the input procedures
+func CurrentInputPortParameter() *Parameter {
+	return currentInputPort
+}
+
+// CurrentOutputPortParameter returns the parameter holding the default port of the output procedures
+func CurrentOutputPortParameter() *Parameter {
+	return currentOutputPort
+}
+
+// CurrentErrorPortParameter returns the parameter holding the default port for error messages
+func CurrentErrorPortParameter() *Parameter {
+	return currentErrorPort
+}
+
+// CurrentInputPort returns the default port of the input procedures in a dynamic environment
+func CurrentInputPort(state *DynamicState) *InputPort {
+	return state.lookup(currentInputPort).(*InputPort)
+}
+
+// CurrentOutputPort returns the default port of the output procedures in a dynamic environment
+func CurrentOutputPort(state *DynamicState) *OutputPort {
+	return state.lookup(currentOutputPort).(*OutputPort)
+}
+
+// CurrentErrorPort returns the default port for error messages in a dynamic environment
+func CurrentErrorPort(state *DynamicState) *OutputPort {
+	return state.lookup(currentErrorPort).(*OutputPort)
+}
+
+// checkInputPort verifies that an input port is open and of the expected kind
+func checkInputPort(port *InputPort, binary bool) error {
+	if port == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	if port.Closed {
+		return errors.NewError(errors.PortError, "given a closed port", "port:", port)
+	}
+	if port.Binary != binary {
+		if binary {
+			return errors.NewError(errors.TypeError, "given a textual port", "port:", port)
+		}
+		return errors.NewError(errors.TypeError, "given a binary port", "port:", port)
+	}
+	return nil
+}
+
+// checkOutputPort verifies that an output port is open and of the expected kind
+func checkOutputPort(port *OutputPort, binary bool) error {
+	if port == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	if port.Closed {
+		return errors.NewError(errors.PortError, "given a closed port", "port:", port)
+	}
+	if port.Binary != binary {
+		if binary {
+			return errors.NewError(errors.TypeError, "given a textual port", "port:", port)
+		}
+		return errors.NewError(errors.TypeError, "given a binary port", "port:", port)
+	}
+	return nil
+}
+
+// checkRange verifies that start and end delimit a slice of a sequence
+func checkRange(start, end, length int) error {
+	if start < 0 || start > length {
+		return errors.NewError(errors.OutOfBoundsError, "given a bad start index", "start:", start)
+	}
+	if end < start || end > length {
+		return errors.NewError(errors.OutOfBoundsError, "given a bad end index", "end:", end)
+	}
+	return nil
+}
+
+// readError wraps an error of the underlying reader
+func readError(err error) error {
+	return errors.NewError(errors.PortError, "encountered error while reading", "err:", err)
+}
+
+// writeError wraps an error of the underlying writer
+func writeError(err error) error {
+	return errors.NewError(errors.PortError, "encountered error while writing", "err:", err)
+}
+
+// decodeChar decodes the leading character of some data, reporting how many bytes it takes
+// or whether the data is too short to hold it. On an invalid sequence it reports how many bytes to skip
+func decodeChar(data []byte, encoding Encoding) (r rune, size int, full bool, err error) {
+	switch encoding {
+	case UTF8Encoding:
+		if !utf8.FullRune(data) {
+			return 0, 0, false, nil
+		}
+		r, size = utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			return 0, 1, true, errors.NewError(errors.ValueError, "given an invalid utf-8 sequence", "data:", data[:1])
+		}
+		return r, size, true, nil
+	case UTF16BEEncoding, UTF16LEEncoding:
+		unit := func(i int) rune {
+			if encoding == UTF16BEEncoding {
+				return rune(data[i])<<8 | rune(data[i+1])
+			}
+			return rune(data[i+1])<<8 | rune(data[i])
+		}
+		if len(data) < 2 {
+			return 0, 0, false, nil
+		}
+		r = unit(0)
+		if !utf16.IsSurrogate(r) {
+			return r, 2, true, nil
+		}
+		if len(data) < 4 {
+			return 0, 0, false, nil
+		}
+		r = utf16.DecodeRune(r, unit(2))
+		if r == utf8.RuneError {
+			return 0, 2, true, errors.NewError(errors.ValueError, "given an invalid utf-16 sequence", "data:", data[:4])
+		}
+		return r, 4, true, nil
+	case Latin1Encoding:
+		if len(data) < 1 {
+			return 0, 0, false, nil
+		}
+		return rune(data[0]), 1, true, nil
+	}
+	return 0, 0, true, errors.NewError(errors.ValueError, "given an unknown encoding", "encoding:", encoding)
+}
+
+// peekChar decodes the next character of a checked port, reporting how many bytes it takes.
+// An invalid sequence is consumed so reading goes on after its error
+func peekChar(port *InputPort) (Object, int, error) {
+	for n := 1; ; n++ {
+		data, err := port.buffer.Peek(n)
+		if err != nil && err != io.EOF {
+			return nil, 0, readError(err)
+		}
+		if len(data) == 0 {
+			return EOF(), 0, nil
+		}
+		r, size, full, derr := decodeChar(data, port.Encoding)
+		if derr != nil {
+			port.buffer.Discard(size)
+			return nil, 0, derr
+		}
+		if full {
+			return NewCharacter(r), size, nil
+		}
+		if err == io.EOF {
+			return nil, 0, errors.NewError(errors.ValueError, "given a partial character at the end of input", "data:", data)
+		}
+	}
+}
+
+// readChar reads a character from a checked port
+func readChar(port *InputPort) (Object, error) {
+	x, size, err := peekChar(port)
+	if err != nil {
+		return nil, err
+	}
+	_, err = port.buffer.Discard(size)
+	if err != nil {
+		return nil, readError(err)
+	}
+	return x, nil
+}
+
+// ReadChar returns the next character of a textual port or the eof object
+func ReadChar(port *InputPort) (Object, error) {
+	if err := checkInputPort(port, false); err != nil {
+		return nil, err
+	}
+	return readChar(port)
+}
+
+// PeekChar returns the next character of a textual port without consuming it
+func PeekChar(port *InputPort) (Object, error) {
+	if err := checkInputPort(port, false); err != nil {
+		return nil, err
+	}
+	x, _, err := peekChar(port)
+	return x, err
+}
+
+// ReadLine returns the next line of a textual port without its line ending
+func ReadLine(port *InputPort) (Object, error) {
+	if err := checkInputPort(port, false); err != nil {
+		return nil, err
+	}
+	elms := make([]Character, 0, 80)
+	for {
+		x, err := readChar(port)
+		if err != nil {
+			return nil, err
+		}
+		if x == EOF() {
+			if len(elms) == 0 {
+				return EOF(), nil
+			}
+			break
+		}
+		if x == NewCharacter('\n') {
+			break
+		}
+		elms = append(elms, x.(Character))
+	}
+	if len(elms) > 0 && elms[len(elms)-1] == '\r' {
+		elms = elms[:len(elms)-1]
+	}
+	return newStringFromCharacters(elms), nil
+}
+
+// ReadString returns a string with at most k characters of a textual port
+func ReadString(port *InputPort, k int) (Object, error) {
+	if err := checkInputPort(port, false); err != nil {
+		return nil, err
+	}
+	if k < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a k < 0", "k:", k)
+	}
+	size := k
+	if size > readChunk {
+		size = readChunk
+	}
+	elms := make([]Character, 0, size)
+	for len(elms) < k {
+		x, err := readChar(port)
+		if err != nil {
+			return nil, err
+		}
+		if x == EOF() {
+			if len(elms) == 0 {
+				return EOF(), nil
+			}
+			break
+		}
+		elms = append(elms, x.(Character))
+	}
+	return newStringFromCharacters(elms), nil
+}
+
+// readChunk is the initial capacity for reads of many characters or bytes,
+// the buffers grow as data arrives
+const readChunk = 4096
+
+// nonBlocking reports whether reading from a reader never waits for data
+func nonBlocking(reader io.Reader) bool {
+	switch reader := reader.(type) {
+	case *strings.Reader, *bytes.Reader, *bytes.Buffer:
+		return true
+	case *os.File:
+		info, err := reader.Stat()
+		return err == nil && info.Mode().IsRegular()
+	}
+	return false
+}
+
+// CharReady reports whether reading a character of a textual port won't wait,
+// because one is already buffered or the port is at the end of input
+func CharReady(port *InputPort) (bool, error) {
+	if err := checkInputPort(port, false); err != nil {
+		return false, err
+	}
+	if nonBlocking(port.Reader) {
+		return true, nil
+	}
+	n := port.buffer.Buffered()
+	if n == 0 {
+		return false, nil
+	}
+	data, err := port.buffer.Peek(n)
+	if err != nil {
+		return false, readError(err)
+	}
+	_, _, full, _ := decodeChar(data, port.Encoding)
+	return full, nil
+}
+
+// ReadU8 returns the next byte of a binary port or the eof object
+func ReadU8(port *InputPort) (Object, error) {
+	if err := checkInputPort(port, true); err != nil {
+		return nil, err
+	}
+	b, err := port.buffer.ReadByte()
+	if err == io.EOF {
+		return EOF(), nil
+	}
+	if err != nil {
+		return nil, readError(err)
+	}
+	return NewFixnum(int64(b)), nil
+}
+
+// PeekU8 returns the next byte of a binary port without consuming it
+func PeekU8(port *InputPort) (Object, error) {
+	if err := checkInputPort(port, true); err != nil {
+		return nil, err
+	}
+	data, err := port.buffer.Peek(1)
+	if err == io.EOF {
+		return EOF(), nil
+	}
+	if err != nil {
+		return nil, readError(err)
+	}
+	return NewFixnum(int64(data[0])), nil
+}
+
+// ReadByteVector returns a byte-vector with at most k bytes of a binary port
+func ReadByteVector(port *InputPort, k int) (Object, error) {
+	if err := checkInputPort(port, true); err != nil {
+		return nil, err
+	}
+	if k < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a k < 0", "k:", k)
+	}
+	buff := bytes.NewBuffer(nil)
+	n, err := io.CopyN(buff, port.buffer, int64(k))
+	if err != nil && err != io.EOF {
+		return nil, readError(err)
+	}
+	if n == 0 && k > 0 {
+		return EOF(), nil
+	}
+	return &ByteVector{
+		Elements: buff.Bytes(),
+		Length:   buff.Len(),
+	}, nil
+}
+
+// ReadByteVectorInto reads bytes of a binary port into a byte-vector slice and returns their count
+func ReadByteVectorInto(port *InputPort, bv *ByteVector, start, end int) (Object, error) {
+	if err := checkInputPort(port, true); err != nil {
+		return nil, err
+	}
+	if bv == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "bv:", bv)
+	}
+	if err := checkRange(start, end, len(bv.Elements)); err != nil {
+		return nil, err
+	}
+	n, err := io.ReadFull(port.buffer, bv.Elements[start:end])
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, readError(err)
+	}
+	if n == 0 && end > start {
+		return EOF(), nil
+	}
+	return NewFixnum(int64(n)), nil
+}
+
+// writeBytes writes raw data to a checked port
+func writeBytes(port *OutputPort, data []byte) error {
+	if port.Buffering == NoBuffering {
+		if err := flushBuffer(port); err != nil {
+			return err
+		}
+		_, err := port.Writer.Write(data)
+		if err != nil {
+			return writeError(err)
+		}
+		return nil
+	}
+	if port.buffer == nil {
+		port.buffer = bufio.NewWriter(port.Writer)
+	}
+	_, err := port.buffer.Write(data)
+	if err != nil {
+		return writeError(err)
+	}
+	if port.Buffering == LineBuffering && bytes.IndexByte(data, '\n') >= 0 {
+		return flushBuffer(port)
+	}
+	return nil
+}
+
+// flushBuffer writes out the data held by the buffer of an output port
+func flushBuffer(port *OutputPort) error {
+	if port.buffer == nil || port.buffer.Buffered() == 0 {
+		return nil
+	}
+	err := port.buffer.Flush()
+	if err != nil {
+		return writeError(err)
+	}
+	return nil
+}
+
+// SetPortBuffering flushes an output port and changes its buffering mode
+func SetPortBuffering(port *OutputPort, buffering Buffering) error {
+	if port == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	if port.Closed {
+		return errors.NewError(errors.PortError, "given a closed port", "port:", port)
+	}
+	if buffering < NoBuffering || buffering > BlockBuffering {
+		return errors.NewError(errors.ValueError, "given an unknown buffering mode", "buffering:", buffering)
+	}
+	if err := flushBuffer(port); err != nil {
+		return err
+	}
+	port.Buffering = buffering
+	return nil
+}
+
+// WriteChar writes a character to a textual port
+func WriteChar(port *OutputPort, c Character) error {
+	if err := checkOutputPort(port, false); err != nil {
+		return err
+	}
+	data := make([]byte, utf8.UTFMax)
+	n := utf8.EncodeRune(data, rune(c))
+	return writeBytes(port, data[:n])
+}
+
+// WriteString writes the characters of a string slice to a textual port
+func WriteString(port *OutputPort, str *String, start, end int) error {
+	if err := checkOutputPort(port, false); err != nil {
+		return err
+	}
+	if str == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "str:", str)
+	}
+	if err := checkRange(start, end, len(str.Elements)); err != nil {
+		return err
+	}
+	data := make([]byte, 0, end-start)
+	for _, c := range str.Elements[start:end] {
+		data = append(data, string(rune(c))...)
+	}
+	return writeBytes(port, data)
+}
+
+// WriteU8 writes a byte to a binary port
+func WriteU8(port *OutputPort, n Fixnum) error {
+	if err := checkOutputPort(port, true); err != nil {
+		return err
+	}
+	if int64(n) < 0 || int64(n) > 255 {
+		return errors.NewError(errors.ValueError, "given a number not in [0, 255]", "n:", n)
+	}
+	return writeBytes(port, []byte{byte(n)})
+}
+
+// WriteByteVector writes the bytes of a byte-vector slice to a binary port
+func WriteByteVector(port *OutputPort, bv *ByteVector, start, end int) error {
+	if err := checkOutputPort(port, true); err != nil {
+		return err
+	}
+	if bv == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "bv:", bv)
+	}
+	if err := checkRange(start, end, len(bv.Elements)); err != nil {
+		return err
+	}
+	return writeBytes(port, bv.Elements[start:end])
+}
+
+// FlushOutputPort writes out any data buffered by the writer of an output port
+func FlushOutputPort(port *OutputPort) error {
+	if port == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	if port.Closed {
+		return errors.NewError(errors.PortError, "given a closed port", "port:", port)
+	}
+	if err := flushBuffer(port); err != nil {
+		return err
+	}
+	flusher, ok := port.Writer.(interface {
+		Flush() error
+	})
+	if !ok {
+		return nil
+	}
+	err := flusher.Flush()
+	if err != nil {
+		return writeError(err)
+	}
+	return nil
+}
+
+// CloseInputPort closes an input port and its reader, closing it again has no effect
+func CloseInputPort(port *InputPort) error {
+	if port == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	if port.Closed {
+		return nil
+	}
+	port.Closed = true
+	closer, ok := port.Reader.(io.Closer)
+	if !ok {
+		return nil
+	}
+	err := closer.Close()
+	if err != nil {
+		return errors.NewError(errors.PortError, "encountered error while closing", "err:", err)
+	}
+	return nil
+}
+
+// CloseOutputPort flushes and closes an output port and its writer, closing it again has no effect
+func CloseOutputPort(port *OutputPort) error {
+	if port == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	if port.Closed {
+		return nil
+	}
+	ferr := FlushOutputPort(port)
+	port.Closed = true
+	if closer, ok := port.Writer.(io.Closer); ok {
+		err := closer.Close()
+		if err != nil && ferr == nil {
+			return errors.NewError(errors.PortError, "encountered error while closing", "err:", err)
+		}
+	}
+	return ferr
+}
+
+// ClosePort closes either an input or an output port
+func ClosePort(port Object) error {
+	switch port := port.(type) {
+	case *InputPort:
+		return CloseInputPort(port)
+	case *OutputPort:
+		return CloseOutputPort(port)
+	}
+	return errors.NewError(errors.TypeError, "given a non port", "port:", port)
+}
+
+// seekerOf returns the seekable reader or writer of an open port
+func seekerOf(x Object) (io.Seeker, error) {
+	var target interface{}
+	switch port := x.(type) {
+	case *InputPort:
+		if port == nil {
+			return nil, errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+		}
+		if port.Closed {
+			return nil, errors.NewError(errors.PortError, "given a closed port", "port:", port)
+		}
+		target = port.Reader
+	case *OutputPort:
+		if port == nil {
+			return nil, errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+		}
+		if port.Closed {
+			return nil, errors.NewError(errors.PortError, "given a closed port", "port:", port)
+		}
+		if err := flushBuffer(port); err != nil {
+			return nil, err
+		}
+		target = port.Writer
+	default:
+		return nil, errors.NewError(errors.TypeError, "given a non port", "port:", x)
+	}
+	seeker, ok := target.(io.Seeker)
+	if !ok {
+		return nil, errors.NewError(errors.PortError, "given a port without position", "port:", x)
+	}
+	return seeker, nil
+}
+
+// PortHasPosition reports whether the position of a port can be read and set
+func PortHasPosition(x Object) bool {
+	switch port := x.(type) {
+	case *InputPort:
+		if port == nil {
+			return false
+		}
+		_, ok := port.Reader.(io.Seeker)
+		return ok
+	case *OutputPort:
+		if port == nil {
+			return false
+		}
+		_, ok := port.Writer.(io.Seeker)
+		return ok
+	}
+	return false
+}
+
+// PortPosition returns the byte offset of the next read or write of a port
+func PortPosition(x Object) (Fixnum, error) {
+	seeker, err := seekerOf(x)
+	if err != nil {
+		return NewFixnum(0), err
+	}
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return NewFixnum(0), errors.WrapError(errors.PortError, "couldn't get the port position", err, "port:", x)
+	}
+	if port, ok := x.(*InputPort); ok {
+		offset -= int64(port.buffer.Buffered())
+	}
+	return NewFixnum(offset), nil
+}
+
+// SetPortPosition moves the next read or write of a port to a byte offset
+func SetPortPosition(x Object, pos Fixnum) error {
+	if int64(pos) < 0 {
+		return errors.NewError(errors.ValueError, "given a position < 0", "pos:", pos)
+	}
+	seeker, err := seekerOf(x)
+	if err != nil {
+		return err
+	}
+	_, err = seeker.Seek(int64(pos), io.SeekStart)
+	if err != nil {
+		return errors.WrapError(errors.PortError, "couldn't set the port position", err, "port:", x)
+	}
+	if port, ok := x.(*InputPort); ok {
+		port.buffer.Reset(port.Reader)
+	}
+	return nil
+}
+
+// InputPortOpen reports whether an input port can still be read
+func InputPortOpen(port *InputPort) (bool, error) {
+	if port == nil {
+		return false, errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	return !port.Closed, nil
+}
+
+// OutputPortOpen reports whether an output port can still be written
+func OutputPortOpen(port *OutputPort) (bool, error) {
+	if port == nil {
+		return false, errors.NewError(errors.NilError, "given a nil reference", "port:", port)
+	}
+	return !port.Closed, nil
+}
+
+// TextualPort reports whether an object is a textual port
+func TextualPort(x Object) bool {
+	switch port := x.(type) {
+	case *InputPort:
+		return port != nil && !port.Binary
+	case *OutputPort:
+		return port != nil && !port.Binary
+	}
+	return false
+}
+
+// BinaryPort reports whether an object is a binary port
+func BinaryPort(x Object) bool {
+	switch port := x.(type) {
+	case *InputPort:
+		return port != nil && port.Binary
+	case *OutputPort:
+		return port != nil && port.Binary
+	}
+	return false
+}
+
+// newStringFromCharacters constructs a String reference sharing the given characters
+func newStringFromCharacters(elms []Character) *String {
+	return &String{
+		Elements: elms,
+		Length:   len(elms),
+	}
+}
+
+// newStringFromGo constructs a String reference with the characters of a go string
+func newStringFromGo(value string) *String {
+	elms := make([]Character, 0, len(value))
+	for _, r := range value {
+		elms = append(elms, NewCharacter(r))
+	}
+	return newStringFromCharacters(elms)
+}
+
+// stringToGo returns the go string with the characters of a String
+func stringToGo(str *String) string {
+	runes := make([]rune, len(str.Elements))
+	for i, c := range str.Elements {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
@@ -0,0 +1,510 @@
+package types
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (rec *closeRecorder) Close() error {
+	rec.closed = true
+	return nil
+}
+
+type failingWriter struct {
+	closeRecorder
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, os.ErrInvalid
+}
+
+func TestPort(t *testing.T) {
+	iport := NewInputPort(strings.NewReader("x"))
+	oport := NewOutputPort(bytes.NewBuffer(nil))
+
+	assert.True(t, reflect.TypeOf(iport).Size() <= 8, "byte width should be at most a word")
+	assert.True(t, reflect.TypeOf(oport).Size() <= 8, "byte width should be at most a word")
+
+	assert.True(t, TextualPort(iport), "it should be a textual port")
+	assert.True(t, TextualPort(oport), "it should be a textual port")
+	assert.False(t, BinaryPort(iport), "it shouldn't be a binary port")
+	assert.False(t, BinaryPort(oport), "it shouldn't be a binary port")
+	assert.True(t, BinaryPort(NewBinaryInputPort(strings.NewReader("x"))), "it should be a binary port")
+	assert.True(t, BinaryPort(NewBinaryOutputPort(bytes.NewBuffer(nil))), "it should be a binary port")
+	assert.False(t, TextualPort(NewFixnum(1)), "it shouldn't be a textual port")
+	assert.False(t, BinaryPort(NewFixnum(1)), "it shouldn't be a binary port")
+
+	assert.NotNil(t, CurrentInputPort(MainDynamicState()), "it shouldn't be nil")
+	assert.NotNil(t, CurrentOutputPort(MainDynamicState()), "it shouldn't be nil")
+	assert.NotNil(t, CurrentErrorPort(MainDynamicState()), "it shouldn't be nil")
+}
+
+func TestTextualInputPort(t *testing.T) {
+	port := NewInputPort(strings.NewReader("héllo\r\nworld\n巨流"))
+
+	c, err := PeekChar(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('h'), c, "they should be equal")
+	c, err = ReadChar(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('h'), c, "they should be equal")
+	c, err = ReadChar(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('é'), c, "they should be equal")
+
+	ready, err := CharReady(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ready, "it should be ready")
+
+	line, err := ReadLine(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromCharacters([]Character{'l', 'l', 'o'}), line, "they should be equal")
+	line, err = ReadLine(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromCharacters([]Character{'w', 'o', 'r', 'l', 'd'}), line, "they should be equal")
+
+	str, err := ReadString(port, 5)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromCharacters([]Character{'巨', '流'}), str, "they should be equal")
+
+	c, err = ReadChar(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), c, "they should be equal")
+	c, err = PeekChar(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), c, "they should be equal")
+	line, err = ReadLine(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), line, "they should be equal")
+	str, err = ReadString(port, 5)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), str, "they should be equal")
+	ready, err = CharReady(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ready, "it should be ready at the end of input")
+
+	pr, pw := io.Pipe()
+	ready, err = CharReady(NewInputPort(pr))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, ready, "it shouldn't be ready without data")
+	pw.Close()
+
+	str, err = ReadString(NewInputPort(strings.NewReader("abc")), int(^uint(0)>>1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromCharacters([]Character{'a', 'b', 'c'}), str, "they should be equal")
+
+	_, err = ReadString(port, -1)
+	assert.Error(t, err, "it should be an error")
+	_, err = ReadChar(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = ReadU8(port)
+	assert.Error(t, err, "it should be an error")
+
+	open, err := InputPortOpen(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, open, "it should be open")
+
+	err = ClosePort(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = ClosePort(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	open, err = InputPortOpen(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, open, "it shouldn't be open")
+
+	_, err = ReadChar(port)
+	assert.Error(t, err, "it should be an error")
+	_, err = InputPortOpen(nil)
+	assert.Error(t, err, "it should be an error")
+	err = ClosePort(NewFixnum(1))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestBinaryInputPort(t *testing.T) {
+	port := NewBinaryInputPort(bytes.NewReader([]byte{1, 2, 3, 4, 5, 6, 7}))
+
+	b, err := PeekU8(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(1), b, "they should be equal")
+	b, err = ReadU8(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(1), b, "they should be equal")
+
+	bv, err := ReadByteVector(port, 2)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, &ByteVector{Elements: []byte{2, 3}, Length: 2}, bv, "they should be equal")
+
+	into, _ := NewByteVector(5, NewFixnum(0))
+	n, err := ReadByteVectorInto(port, into, 1, 5)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(4), n, "they should be equal")
+	assert.Equal(t, []byte{0, 4, 5, 6, 7}, into.Elements, "they should be equal")
+
+	b, err = ReadU8(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), b, "they should be equal")
+	b, err = PeekU8(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), b, "they should be equal")
+	bv, err = ReadByteVector(port, 2)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), bv, "they should be equal")
+	bv, err = ReadByteVector(NewBinaryInputPort(bytes.NewReader([]byte{8, 9})), int(^uint(0)>>1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, &ByteVector{Elements: []byte{8, 9}, Length: 2}, bv, "they should be equal")
+	n, err = ReadByteVectorInto(port, into, 0, 5)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), n, "they should be equal")
+
+	_, err = ReadByteVector(port, -1)
+	assert.Error(t, err, "it should be an error")
+	_, err = ReadByteVectorInto(port, nil, 0, 0)
+	assert.Error(t, err, "it should be an error")
+	_, err = ReadByteVectorInto(port, into, 3, 2)
+	assert.Error(t, err, "it should be an error")
+	_, err = ReadByteVectorInto(port, into, 0, 6)
+	assert.Error(t, err, "it should be an error")
+	_, err = ReadChar(port)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestTextualOutputPort(t *testing.T) {
+	rec := &closeRecorder{}
+	port := NewOutputPort(rec)
+	str := newStringFromCharacters([]Character{'h', 'é', 'l', 'l', 'o'})
+
+	err := WriteChar(port, NewCharacter('巨'))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteString(port, str, 0, 5)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteString(port, str, 1, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = FlushOutputPort(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "巨hélloél", rec.String(), "they should be equal")
+
+	err = WriteString(port, str, 3, 1)
+	assert.Error(t, err, "it should be an error")
+	err = WriteString(port, nil, 0, 0)
+	assert.Error(t, err, "it should be an error")
+	err = WriteU8(port, NewFixnum(1))
+	assert.Error(t, err, "it should be an error")
+	err = WriteChar(nil, NewCharacter('x'))
+	assert.Error(t, err, "it should be an error")
+
+	open, err := OutputPortOpen(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, open, "it should be open")
+
+	err = ClosePort(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, rec.closed, "it should close the writer")
+
+	open, err = OutputPortOpen(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, open, "it shouldn't be open")
+
+	err = WriteChar(port, NewCharacter('x'))
+	assert.Error(t, err, "it should be an error")
+	err = FlushOutputPort(port)
+	assert.Error(t, err, "it should be an error")
+	_, err = OutputPortOpen(nil)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestBinaryOutputPort(t *testing.T) {
+	buff := bytes.NewBuffer(nil)
+	port := NewBinaryOutputPort(buff)
+	bv := &ByteVector{Elements: []byte{10, 20, 30}, Length: 3}
+
+	err := WriteU8(port, NewFixnum(255))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteByteVector(port, bv, 0, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteByteVector(port, bv, 2, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, []byte{255, 10, 20, 30, 30}, buff.Bytes(), "they should be equal")
+
+	err = WriteU8(port, NewFixnum(256))
+	assert.Error(t, err, "it should be an error")
+	err = WriteU8(port, NewFixnum(-1))
+	assert.Error(t, err, "it should be an error")
+	err = WriteByteVector(port, bv, 0, 4)
+	assert.Error(t, err, "it should be an error")
+	err = WriteByteVector(port, nil, 0, 0)
+	assert.Error(t, err, "it should be an error")
+	err = WriteChar(port, NewCharacter('x'))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestStringPort(t *testing.T) {
+	iport, err := OpenInputString(newStringFromGo("巨流\nx"))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, TextualPort(iport), "it should be a textual port")
+
+	line, err := ReadLine(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("巨流"), line, "they should be equal")
+	c, err := ReadChar(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('x'), c, "they should be equal")
+	c, err = ReadChar(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, EOF(), c, "they should be equal")
+
+	_, err = OpenInputString(nil)
+	assert.Error(t, err, "it should be an error")
+
+	oport := OpenOutputString()
+	assert.True(t, TextualPort(oport), "it should be a textual port")
+
+	str, err := GetOutputString(oport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo(""), str, "they should be equal")
+
+	err = WriteChar(oport, NewCharacter('é'))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteString(oport, newStringFromGo("tude"), 0, 4)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	str, err = GetOutputString(oport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("étude"), str, "they should be equal")
+
+	_, err = GetOutputString(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = GetOutputString(NewOutputPort(&closeRecorder{}))
+	assert.Error(t, err, "it should be an error")
+	_, err = GetOutputString(OpenOutputByteVector())
+	assert.Error(t, err, "it should be an error")
+
+	state := MainDynamicState()
+	saved := CurrentOutputPort(state)
+	str, err = WithOutputToString(state, func() (Object, error) {
+		return nil, WriteString(CurrentOutputPort(state), newStringFromGo("inside"), 0, 6)
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("inside"), str, "they should be equal")
+	assert.True(t, saved == CurrentOutputPort(state), "it should restore the current output port")
+
+	_, err = WithOutputToString(state, func() (Object, error) {
+		return nil, WriteU8(CurrentOutputPort(state), NewFixnum(1))
+	})
+	assert.Error(t, err, "it should be an error")
+	assert.True(t, saved == CurrentOutputPort(state), "it should restore the current output port")
+}
+
+func TestByteVectorPort(t *testing.T) {
+	bv := &ByteVector{Elements: []byte{1, 2, 3}, Length: 3}
+
+	iport, err := OpenInputByteVector(bv)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, BinaryPort(iport), "it should be a binary port")
+
+	bv.Elements[0] = 9
+	b, err := ReadU8(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(1), b, "it should read a copy")
+	data, err := ReadByteVector(iport, 5)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, &ByteVector{Elements: []byte{2, 3}, Length: 2}, data, "they should be equal")
+
+	_, err = OpenInputByteVector(nil)
+	assert.Error(t, err, "it should be an error")
+
+	oport := OpenOutputByteVector()
+	assert.True(t, BinaryPort(oport), "it should be a binary port")
+
+	err = WriteU8(oport, NewFixnum(7))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteByteVector(oport, bv, 0, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	out, err := GetOutputByteVector(oport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, &ByteVector{Elements: []byte{7, 9, 2, 3}, Length: 4}, out, "they should be equal")
+
+	_, err = GetOutputByteVector(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = GetOutputByteVector(OpenOutputString())
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestTranscodedInputPort(t *testing.T) {
+	be, err := NewTranscodedInputPort(bytes.NewReader([]byte{0x00, 'h', 0x5d, 0xe8, 0xd8, 0x3d, 0xde, 0x00, 0x00}), UTF16BEEncoding)
+	assert.NoError(t, err, "it shouldn't be an error")
+	str, err := ReadString(be, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("h巨😀"), str, "they should be equal")
+	_, err = ReadChar(be)
+	assert.Error(t, err, "a partial character should be an error")
+
+	le, err := NewTranscodedInputPort(bytes.NewReader([]byte{'h', 0x00, 0x3d, 0xd8, 0x00, 0xde}), UTF16LEEncoding)
+	assert.NoError(t, err, "it shouldn't be an error")
+	c, err := PeekChar(le)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('h'), c, "they should be equal")
+	str, err = ReadString(le, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("h😀"), str, "they should be equal")
+
+	latin, err := NewTranscodedInputPort(bytes.NewReader([]byte{'c', 0xe9, 0xff}), Latin1Encoding)
+	assert.NoError(t, err, "it shouldn't be an error")
+	str, err = ReadString(latin, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("céÿ"), str, "they should be equal")
+
+	partial := NewInputPort(bytes.NewReader([]byte{'a', 0xe5, 0xb7}))
+	c, err = ReadChar(partial)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('a'), c, "they should be equal")
+	_, err = PeekChar(partial)
+	assert.Error(t, err, "a partial character should be an error")
+	_, err = ReadChar(partial)
+	assert.Error(t, err, "a partial character should be an error")
+
+	invalid := NewInputPort(bytes.NewReader([]byte{0xff, 'a', 0xc0, '\n', 'b'}))
+	_, err = ReadChar(invalid)
+	assert.Error(t, err, "an invalid character should be an error")
+	c, err = ReadChar(invalid)
+	assert.NoError(t, err, "it should read past an invalid character")
+	assert.Equal(t, NewCharacter('a'), c, "they should be equal")
+	_, err = ReadLine(invalid)
+	assert.Error(t, err, "an invalid character should be an error")
+	line, err := ReadLine(invalid)
+	assert.NoError(t, err, "it should read past an invalid character")
+	assert.Equal(t, newStringFromGo(""), line, "they should be equal")
+	str, err = ReadString(invalid, 5)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("b"), str, "they should be equal")
+
+	lone, _ := NewTranscodedInputPort(bytes.NewReader([]byte{0xd8, 0x3d, 0x00, 'x'}), UTF16BEEncoding)
+	_, err = ReadChar(lone)
+	assert.Error(t, err, "a lone surrogate should be an error")
+	c, err = ReadChar(lone)
+	assert.NoError(t, err, "it should read past a lone surrogate")
+	assert.Equal(t, NewCharacter('x'), c, "they should be equal")
+
+	_, err = NewTranscodedInputPort(bytes.NewReader(nil), Encoding(42))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestPortPosition(t *testing.T) {
+	iport, err := OpenInputString(newStringFromGo("hello"))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, PortHasPosition(iport), "it should have a position")
+
+	_, err = ReadString(iport, 2)
+	assert.NoError(t, err, "it shouldn't be an error")
+	pos, err := PortPosition(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(2), pos, "they should be equal")
+
+	err = SetPortPosition(iport, NewFixnum(4))
+	assert.NoError(t, err, "it shouldn't be an error")
+	c, err := ReadChar(iport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewCharacter('o'), c, "they should be equal")
+
+	err = SetPortPosition(iport, NewFixnum(-1))
+	assert.Error(t, err, "it should be an error")
+
+	file, err := ioutil.TempFile("", "scheme")
+	assert.NoError(t, err, "it shouldn't be an error")
+	defer os.Remove(file.Name())
+	oport := NewBinaryOutputPort(file)
+	err = SetPortBuffering(oport, BlockBuffering)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteByteVector(oport, &ByteVector{Elements: []byte{1, 2, 3}, Length: 3}, 0, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	pos, err = PortPosition(oport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(3), pos, "they should be equal")
+	err = SetPortPosition(oport, NewFixnum(1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteU8(oport, NewFixnum(9))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = CloseOutputPort(oport)
+	assert.NoError(t, err, "it shouldn't be an error")
+	data, err := ioutil.ReadFile(file.Name())
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, []byte{1, 9, 3}, data, "they should be equal")
+
+	_, err = PortPosition(oport)
+	assert.Error(t, err, "it should be an error")
+
+	stream := NewInputPort(bytes.NewBufferString("hello"))
+	assert.False(t, PortHasPosition(stream), "it shouldn't have a position")
+	assert.False(t, PortHasPosition(NewFixnum(1)), "it shouldn't have a position")
+	_, err = PortPosition(stream)
+	assert.Error(t, err, "it should be an error")
+	err = SetPortPosition(stream, NewFixnum(0))
+	assert.Error(t, err, "it should be an error")
+	_, err = PortPosition(NewFixnum(1))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestPortBuffering(t *testing.T) {
+	buff := bytes.NewBuffer(nil)
+	port := NewOutputPort(buff)
+
+	err := SetPortBuffering(port, BlockBuffering)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteString(port, newStringFromGo("ab\n"), 0, 3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "", buff.String(), "it should be buffered")
+	err = FlushOutputPort(port)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "ab\n", buff.String(), "they should be equal")
+
+	err = SetPortBuffering(port, LineBuffering)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteChar(port, NewCharacter('c'))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "ab\n", buff.String(), "it should be buffered")
+	err = WriteChar(port, NewCharacter('\n'))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "ab\nc\n", buff.String(), "they should be equal")
+
+	err = WriteChar(port, NewCharacter('d'))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = SetPortBuffering(port, NoBuffering)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "ab\nc\nd", buff.String(), "it should flush when changing modes")
+
+	str := OpenOutputString()
+	err = SetPortBuffering(str, BlockBuffering)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = WriteChar(str, NewCharacter('x'))
+	assert.NoError(t, err, "it shouldn't be an error")
+	out, err := GetOutputString(str)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromGo("x"), out, "they should be equal")
+
+	failing := &failingWriter{}
+	bad := NewOutputPort(failing)
+	SetPortBuffering(bad, BlockBuffering)
+	err = WriteChar(bad, NewCharacter('x'))
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = CloseOutputPort(bad)
+	assert.Error(t, err, "it should be an error")
+	assert.True(t, failing.closed, "it should close the writer when flushing fails")
+
+	err = SetPortBuffering(port, Buffering(42))
+	assert.Error(t, err, "it should be an error")
+	err = SetPortBuffering(nil, NoBuffering)
+	assert.Error(t, err, "it should be an error")
+}
@@ -0,0 +1,255 @@
+package types
+
+import (
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// Promise is the type of promise values
+type Promise struct {
+	box *promiseBox
+}
+
+// promiseBox holds the state of a promise, promises forcing to each other end up sharing it
+type promiseBox struct {
+	done  bool
+	value Object
+	thunk func() (Object, error)
+}
+
+// newDonePromise constructs a forced Promise reference
+func newDonePromise(value Object) *Promise {
+	return &Promise{
+		box: &promiseBox{
+			done:  true,
+			value: value,
+		},
+	}
+}
+
+// MakePromise constructs a forced Promise reference, a promise is returned as is
+func MakePromise(value Object) *Promise {
+	if p, ok := value.(*Promise); ok && p != nil {
+		return p
+	}
+	return newDonePromise(value)
+}
+
+// DelayForce constructs a Promise reference whose value is the value of the promise returned by a thunk
+func DelayForce(thunk func() (Object, error)) (*Promise, error) {
+	if thunk == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "thunk:", thunk)
+	}
+	return &Promise{
+		box: &promiseBox{
+			thunk: thunk,
+		},
+	}, nil
+}
+
+// Delay constructs a Promise reference whose value is the value returned by a thunk
+func Delay(thunk func() (Object, error)) (*Promise, error) {
+	if thunk == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "thunk:", thunk)
+	}
+	return DelayForce(func() (Object, error) {
+		value, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		return newDonePromise(value), nil
+	})
+}
+
+// Force returns the value of a promise computing it the first time, other objects are returned as is.
+// Chains of delay-force are followed iteratively so they run in constant space
+func Force(x Object) (Object, error) {
+	p, ok := x.(*Promise)
+	if !ok {
+		return x, nil
+	}
+	if p == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "p:", p)
+	}
+	for !p.box.done {
+		x, err := p.box.thunk()
+		if err != nil {
+			return nil, err
+		}
+		next, ok := x.(*Promise)
+		if !ok || next == nil {
+			return nil, errors.NewError(errors.TypeError, "given a delay-force that didn't produce a promise", "x:", x)
+		}
+		if !p.box.done {
+			p.box.done = next.box.done
+			p.box.value = next.box.value
+			p.box.thunk = next.box.thunk
+			next.box = p.box
+		}
+	}
+	return p.box.value, nil
+}
+
+// StreamNull returns the empty stream
+func StreamNull() *Promise {
+	return newDonePromise(Null())
+}
+
+// StreamCons constructs a stream whose first element and rest are computed on demand
+func StreamCons(car func() (Object, error), cdr func() (Object, error)) (*Promise, error) {
+	first, err := Delay(car)
+	if err != nil {
+		return nil, err
+	}
+	rest, err := DelayForce(cdr)
+	if err != nil {
+		return nil, err
+	}
+	return newDonePromise(&Pair{
+		Car: first,
+		Cdr: rest,
+	}), nil
+}
+
+// streamPair forces a stream into either the empty list or a pair of promises
+func streamPair(s Object) (*Pair, error) {
+	x, err := Force(s)
+	if err != nil {
+		return nil, err
+	}
+	if x == Null() {
+		return nil, nil
+	}
+	cons, ok := x.(*Pair)
+	if !ok {
+		return nil, errors.NewError(errors.TypeError, "given a non stream", "s:", s)
+	}
+	return cons, nil
+}
+
+// StreamEmpty reports whether a stream is empty
+func StreamEmpty(s Object) (bool, error) {
+	cons, err := streamPair(s)
+	return cons == nil && err == nil, err
+}
+
+// StreamCar returns the first element of a non empty stream
+func StreamCar(s Object) (Object, error) {
+	cons, err := streamPair(s)
+	if err != nil {
+		return nil, err
+	}
+	if cons == nil {
+		return nil, errors.NewError(errors.ValueError, "given an empty stream", "s:", s)
+	}
+	return Force(cons.Car)
+}
+
+// StreamCdr returns the rest of a non empty stream
+func StreamCdr(s Object) (Object, error) {
+	cons, err := streamPair(s)
+	if err != nil {
+		return nil, err
+	}
+	if cons == nil {
+		return nil, errors.NewError(errors.ValueError, "given an empty stream", "s:", s)
+	}
+	return cons.Cdr, nil
+}
+
+// StreamMap returns the stream of the results of a procedure on the elements of a stream
+func StreamMap(proc func(Object) (Object, error), s Object) (*Promise, error) {
+	return DelayForce(func() (Object, error) {
+		cons, err := streamPair(s)
+		if err != nil {
+			return nil, err
+		}
+		if cons == nil {
+			return StreamNull(), nil
+		}
+		return StreamCons(func() (Object, error) {
+			x, err := Force(cons.Car)
+			if err != nil {
+				return nil, err
+			}
+			return proc(x)
+		}, func() (Object, error) {
+			return StreamMap(proc, cons.Cdr)
+		})
+	})
+}
+
+// StreamFilter returns the stream of the elements of a stream satisfying a predicate
+func StreamFilter(pred func(Object) (bool, error), s Object) (*Promise, error) {
+	return DelayForce(func() (Object, error) {
+		for {
+			cons, err := streamPair(s)
+			if err != nil {
+				return nil, err
+			}
+			if cons == nil {
+				return StreamNull(), nil
+			}
+			x, err := Force(cons.Car)
+			if err != nil {
+				return nil, err
+			}
+			ok, err := pred(x)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rest := cons.Cdr
+				return StreamCons(func() (Object, error) {
+					return x, nil
+				}, func() (Object, error) {
+					return StreamFilter(pred, rest)
+				})
+			}
+			s = cons.Cdr
+		}
+	})
+}
+
+// StreamTake returns the stream of at most the first n elements of a stream
+func StreamTake(n int, s Object) (*Promise, error) {
+	if n < 0 {
+		return nil, errors.NewError(errors.ValueError, "given an n < 0", "n:", n)
+	}
+	return DelayForce(func() (Object, error) {
+		if n == 0 {
+			return StreamNull(), nil
+		}
+		cons, err := streamPair(s)
+		if err != nil {
+			return nil, err
+		}
+		if cons == nil {
+			return StreamNull(), nil
+		}
+		return StreamCons(func() (Object, error) {
+			return Force(cons.Car)
+		}, func() (Object, error) {
+			return StreamTake(n-1, cons.Cdr)
+		})
+	})
+}
+
+// StreamToList returns the list of the elements of a finite stream
+func StreamToList(s Object) (Object, error) {
+	elms := []Object{}
+	for {
+		cons, err := streamPair(s)
+		if err != nil {
+			return nil, err
+		}
+		if cons == nil {
+			return newList(elms), nil
+		}
+		x, err := Force(cons.Car)
+		if err != nil {
+			return nil, err
+		}
+		elms = append(elms, x)
+		s = cons.Cdr
+	}
+}
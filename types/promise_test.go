@@ -0,0 +1,156 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eduardoacuna/scheme/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromise(t *testing.T) {
+	calls := 0
+	p, err := Delay(func() (Object, error) {
+		calls++
+		return NewFixnum(42), nil
+	})
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, reflect.TypeOf(p).Size() <= 8, "byte width should be at most a word")
+
+	x, err := Force(p)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(42), x, "they should be equal")
+	x, err = Force(p)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(42), x, "they should be equal")
+	assert.Equal(t, 1, calls, "it should compute the value once")
+
+	done := MakePromise(NewFixnum(7))
+	x, err = Force(done)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(7), x, "they should be equal")
+	assert.True(t, MakePromise(done) == done, "they should be the same")
+
+	x, err = Force(NewFixnum(3))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(3), x, "they should be equal")
+
+	nested, _ := Delay(func() (Object, error) {
+		return done, nil
+	})
+	x, err = Force(nested)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, x == done, "delay shouldn't force its value")
+
+	bad, _ := DelayForce(func() (Object, error) {
+		return NewFixnum(1), nil
+	})
+	_, err = Force(bad)
+	assert.Error(t, err, "it should be an error")
+
+	failing, _ := Delay(func() (Object, error) {
+		return nil, errors.NewError(errors.ValueError, "failing on purpose")
+	})
+	_, err = Force(failing)
+	assert.Error(t, err, "it should be an error")
+
+	_, err = Delay(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = DelayForce(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = Force((*Promise)(nil))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestPromiseReentrancy(t *testing.T) {
+	count := 0
+	limit := 5
+	var p *Promise
+	p, _ = Delay(func() (Object, error) {
+		count++
+		if count > limit {
+			return NewFixnum(int64(count)), nil
+		}
+		return Force(p)
+	})
+	x, err := Force(p)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(6), x, "they should be equal")
+	limit = 10
+	x, err = Force(p)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(6), x, "they should be equal")
+}
+
+func TestPromiseChain(t *testing.T) {
+	var loop func(n int) *Promise
+	loop = func(n int) *Promise {
+		p, _ := DelayForce(func() (Object, error) {
+			if n == 0 {
+				return MakePromise(GetSymbol("done")), nil
+			}
+			return loop(n - 1), nil
+		})
+		return p
+	}
+	x, err := Force(loop(100000))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, GetSymbol("done"), x, "they should be equal")
+}
+
+func TestStream(t *testing.T) {
+	var naturals func(n int64) *Promise
+	naturals = func(n int64) *Promise {
+		s, _ := StreamCons(func() (Object, error) {
+			return NewFixnum(n), nil
+		}, func() (Object, error) {
+			return naturals(n + 1), nil
+		})
+		return s
+	}
+
+	empty, err := StreamEmpty(StreamNull())
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, empty, "it should be empty")
+	empty, err = StreamEmpty(naturals(0))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, empty, "it shouldn't be empty")
+
+	first, err := StreamCar(naturals(3))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(3), first, "they should be equal")
+	rest, err := StreamCdr(naturals(3))
+	assert.NoError(t, err, "it shouldn't be an error")
+	second, err := StreamCar(rest)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(4), second, "they should be equal")
+
+	evens, err := StreamFilter(func(x Object) (bool, error) {
+		return x.(Fixnum)%2 == 0, nil
+	}, naturals(0))
+	assert.NoError(t, err, "it shouldn't be an error")
+	squares, err := StreamMap(func(x Object) (Object, error) {
+		return x.(Fixnum) * x.(Fixnum), nil
+	}, evens)
+	assert.NoError(t, err, "it shouldn't be an error")
+	taken, err := StreamTake(4, squares)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	list, err := StreamToList(taken)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newList([]Object{NewFixnum(0), NewFixnum(4), NewFixnum(16), NewFixnum(36)}), list, "they should be equal")
+
+	short, _ := StreamTake(5, StreamNull())
+	list, err = StreamToList(short)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, Null(), list, "they should be equal")
+
+	_, err = StreamCar(StreamNull())
+	assert.Error(t, err, "it should be an error")
+	_, err = StreamCdr(StreamNull())
+	assert.Error(t, err, "it should be an error")
+	_, err = StreamCar(MakePromise(NewFixnum(1)))
+	assert.Error(t, err, "it should be an error")
+	_, err = StreamTake(-1, StreamNull())
+	assert.Error(t, err, "it should be an error")
+}
@@ -0,0 +1,216 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// RecordType is the type of record-type descriptors
+type RecordType struct {
+	Name   string
+	Parent *RecordType
+	Fields []*Symbol
+}
+
+// NewRecordType constructs a RecordType reference extending an optional parent with new fields
+func NewRecordType(name string, parent *RecordType, fields ...*Symbol) (*RecordType, error) {
+	seen := map[*Symbol]bool{}
+	for _, field := range allFields(parent) {
+		seen[field] = true
+	}
+	for _, field := range fields {
+		if field == nil {
+			return nil, errors.NewError(errors.NilError, "given a nil reference", "field:", field)
+		}
+		if seen[field] {
+			return nil, errors.NewError(errors.ValueError, "given a duplicated field", "field:", field.Name)
+		}
+		seen[field] = true
+	}
+	elms := make([]*Symbol, len(fields))
+	copy(elms, fields)
+	return &RecordType{
+		Name:   name,
+		Parent: parent,
+		Fields: elms,
+	}, nil
+}
+
+// allFields returns the inherited fields of a record type followed by its own
+func allFields(rtd *RecordType) []*Symbol {
+	if rtd == nil {
+		return nil
+	}
+	return append(allFields(rtd.Parent), rtd.Fields...)
+}
+
+// RecordTypeName returns the name of a record type
+func RecordTypeName(rtd *RecordType) (string, error) {
+	if rtd == nil {
+		return "", errors.NewError(errors.NilError, "given a nil reference", "rtd:", rtd)
+	}
+	return rtd.Name, nil
+}
+
+// RecordTypeParent returns the record type extended by a record type, or nil
+func RecordTypeParent(rtd *RecordType) (*RecordType, error) {
+	if rtd == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "rtd:", rtd)
+	}
+	return rtd.Parent, nil
+}
+
+// RecordTypeFields returns the list of field names a record type adds to its parent
+func RecordTypeFields(rtd *RecordType) (Object, error) {
+	if rtd == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "rtd:", rtd)
+	}
+	elms := make([]Object, len(rtd.Fields))
+	for i, field := range rtd.Fields {
+		elms[i] = field
+	}
+	return newList(elms), nil
+}
+
+// recordFieldIndex returns the position of a field within the records of a record type
+func recordFieldIndex(rtd *RecordType, field *Symbol) (int, error) {
+	if rtd == nil {
+		return 0, errors.NewError(errors.NilError, "given a nil reference", "rtd:", rtd)
+	}
+	for i, f := range allFields(rtd) {
+		if f == field {
+			return i, nil
+		}
+	}
+	return 0, errors.NewError(errors.ValueError, "given an unknown field", "field:", field)
+}
+
+// Record is the type of record values
+type Record struct {
+	Type   *RecordType
+	Fields []Object
+}
+
+// MakeRecord constructs a Record reference with a value for every field of its record type
+func MakeRecord(rtd *RecordType, values ...Object) (*Record, error) {
+	if rtd == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "rtd:", rtd)
+	}
+	fields := allFields(rtd)
+	if len(values) != len(fields) {
+		return nil, errors.NewError(errors.ValueError, "given a wrong number of field values", "expected:", len(fields), "given:", len(values))
+	}
+	elms := make([]Object, len(values))
+	copy(elms, values)
+	return &Record{
+		Type:   rtd,
+		Fields: elms,
+	}, nil
+}
+
+// RecordInstance reports whether an object is a record of a record type or of one extending it
+func RecordInstance(rtd *RecordType, x Object) bool {
+	rec, ok := x.(*Record)
+	if !ok || rec == nil {
+		return false
+	}
+	for t := rec.Type; t != nil; t = t.Parent {
+		if t == rtd {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordConstructor returns a procedure building records from values for the given fields,
+// the rest of the fields start undefined
+func RecordConstructor(rtd *RecordType, fields ...*Symbol) (func(...Object) (*Record, error), error) {
+	if rtd == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "rtd:", rtd)
+	}
+	indices := make([]int, len(fields))
+	for i, field := range fields {
+		index, err := recordFieldIndex(rtd, field)
+		if err != nil {
+			return nil, err
+		}
+		indices[i] = index
+	}
+	size := len(allFields(rtd))
+	return func(values ...Object) (*Record, error) {
+		if len(values) != len(indices) {
+			return nil, errors.NewError(errors.ValueError, "given a wrong number of field values", "expected:", len(indices), "given:", len(values))
+		}
+		elms := make([]Object, size)
+		for i := range elms {
+			elms[i] = Undefined()
+		}
+		for i, index := range indices {
+			elms[index] = values[i]
+		}
+		return &Record{
+			Type:   rtd,
+			Fields: elms,
+		}, nil
+	}, nil
+}
+
+// RecordAccessor returns a procedure reading a field of the records of a record type
+func RecordAccessor(rtd *RecordType, field *Symbol) (func(Object) (Object, error), error) {
+	index, err := recordFieldIndex(rtd, field)
+	if err != nil {
+		return nil, err
+	}
+	return func(x Object) (Object, error) {
+		if !RecordInstance(rtd, x) {
+			return nil, errors.NewError(errors.TypeError, "given a record of another type", "expected:", rtd.Name, "x:", x)
+		}
+		return x.(*Record).Fields[index], nil
+	}, nil
+}
+
+// RecordModifier returns a procedure assigning a field of the records of a record type
+func RecordModifier(rtd *RecordType, field *Symbol) (func(Object, Object) error, error) {
+	index, err := recordFieldIndex(rtd, field)
+	if err != nil {
+		return nil, err
+	}
+	return func(x Object, value Object) error {
+		if !RecordInstance(rtd, x) {
+			return errors.NewError(errors.TypeError, "given a record of another type", "expected:", rtd.Name, "x:", x)
+		}
+		x.(*Record).Fields[index] = value
+		return nil
+	}, nil
+}
+
+// String makes the printed representation of a record
+func (rec *Record) String() string {
+	return recordString(rec, map[*Record]bool{})
+}
+
+// recordString makes the printed representation of a record, a record found again while printing
+// its own fields is printed by its type name alone so self references don't loop
+func recordString(rec *Record, printing map[*Record]bool) string {
+	if printing[rec] {
+		return fmt.Sprintf("#<%s>", rec.Type.Name)
+	}
+	printing[rec] = true
+	defer delete(printing, rec)
+	strs := []string{rec.Type.Name}
+	for i, field := range allFields(rec.Type) {
+		value := "<nil>"
+		switch x := rec.Fields[i].(type) {
+		case *Record:
+			if x != nil {
+				value = recordString(x, printing)
+			}
+		default:
+			value = fmt.Sprint(x)
+		}
+		strs = append(strs, fmt.Sprintf("%s: %s", field.Name, value))
+	}
+	return fmt.Sprintf("#<%s>", strings.Join(strs, " "))
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordType(t *testing.T) {
+	x, y, z := GetSymbol("x"), GetSymbol("y"), GetSymbol("z")
+
+	point, err := NewRecordType("point", nil, x, y)
+	assert.NoError(t, err, "it shouldn't be an error")
+	point3, err := NewRecordType("point3", point, z)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	assert.True(t, reflect.TypeOf(point).Size() <= 8, "byte width should be at most a word")
+
+	name, err := RecordTypeName(point3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "point3", name, "they should be equal")
+
+	parent, err := RecordTypeParent(point3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, parent == point, "they should be the same")
+
+	fields, err := RecordTypeFields(point3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newList([]Object{z}), fields, "they should be equal")
+	fields, err = RecordTypeFields(point)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newList([]Object{x, y}), fields, "they should be equal")
+
+	_, err = NewRecordType("bad", nil, x, x)
+	assert.Error(t, err, "it should be an error")
+	_, err = NewRecordType("bad", point, x)
+	assert.Error(t, err, "it should be an error")
+	_, err = NewRecordType("bad", nil, nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = RecordTypeName(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = RecordTypeParent(nil)
+	assert.Error(t, err, "it should be an error")
+	_, err = RecordTypeFields(nil)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestRecord(t *testing.T) {
+	x, y, z := GetSymbol("x"), GetSymbol("y"), GetSymbol("z")
+	point, _ := NewRecordType("point", nil, x, y)
+	point3, _ := NewRecordType("point3", point, z)
+	other, _ := NewRecordType("other", nil, x)
+
+	p, err := MakeRecord(point, NewFixnum(1), NewFixnum(2))
+	assert.NoError(t, err, "it shouldn't be an error")
+	p2, err := MakeRecord(point, NewFixnum(1), NewFixnum(2))
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	assert.True(t, reflect.TypeOf(p).Size() <= 8, "byte width should be at most a word")
+	assert.Equal(t, p, p2, "they should be equal")
+	assert.False(t, p == p2, "they shouldn't be the same")
+	assert.Equal(t, "#<point x: 1 y: 2>", p.String(), "they should be equal")
+
+	_, err = MakeRecord(point, NewFixnum(1))
+	assert.Error(t, err, "it should be an error")
+	_, err = MakeRecord(nil)
+	assert.Error(t, err, "it should be an error")
+
+	makePoint3, err := RecordConstructor(point3, z, x)
+	assert.NoError(t, err, "it shouldn't be an error")
+	p3, err := makePoint3(NewFixnum(3), NewFixnum(1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, []Object{NewFixnum(1), Undefined(), NewFixnum(3)}, p3.Fields, "they should be equal")
+	_, err = makePoint3(NewFixnum(3))
+	assert.Error(t, err, "it should be an error")
+	_, err = RecordConstructor(point, z)
+	assert.Error(t, err, "it should be an error")
+	_, err = RecordConstructor(nil)
+	assert.Error(t, err, "it should be an error")
+
+	assert.True(t, RecordInstance(point, p), "it should be a point")
+	assert.True(t, RecordInstance(point, p3), "it should be a point")
+	assert.True(t, RecordInstance(point3, p3), "it should be a point3")
+	assert.False(t, RecordInstance(point3, p), "it shouldn't be a point3")
+	assert.False(t, RecordInstance(other, p), "it shouldn't be an other")
+	assert.False(t, RecordInstance(point, NewFixnum(1)), "it shouldn't be a point")
+
+	pointX, err := RecordAccessor(point, x)
+	assert.NoError(t, err, "it shouldn't be an error")
+	setPointY, err := RecordModifier(point, y)
+	assert.NoError(t, err, "it shouldn't be an error")
+	point3Z, err := RecordAccessor(point3, z)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	v, err := pointX(p3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(1), v, "they should be equal")
+	v, err = point3Z(p3)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(3), v, "they should be equal")
+
+	err = setPointY(p3, NewFixnum(5))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, "#<point3 x: 1 y: 5 z: 3>", p3.String(), "they should be equal")
+
+	value, next, prev := GetSymbol("value"), GetSymbol("next"), GetSymbol("prev")
+	node, _ := NewRecordType("node", nil, value, next, prev)
+	n1, _ := MakeRecord(node, NewFixnum(1), NewFixnum(0), NewFixnum(0))
+	n2, _ := MakeRecord(node, NewFixnum(2), NewFixnum(0), n1)
+	n1.Fields[1] = n2
+	assert.Equal(t, "#<node value: 1 next: #<node value: 2 next: 0 prev: #<node>> prev: 0>", n1.String(), "they should be equal")
+	n1.Fields[1] = n1
+	assert.Equal(t, "#<node value: 1 next: #<node> prev: 0>", n1.String(), "they should be equal")
+	err = setPointY(n1, NewFixnum(5))
+	assert.Error(t, err, "it should be an error")
+	assert.Contains(t, err.Error(), "#<node>", "it should print the self reference opaquely")
+
+	_, err = point3Z(p)
+	assert.Error(t, err, "it should be an error")
+	err = setPointY(NewFixnum(1), NewFixnum(5))
+	assert.Error(t, err, "it should be an error")
+	_, err = RecordAccessor(point, z)
+	assert.Error(t, err, "it should be an error")
+	_, err = RecordModifier(nil, x)
+	assert.Error(t, err, "it should be an error")
+}
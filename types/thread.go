@@ -0,0 +1,286 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/eduardoacuna/scheme/errors"
+)
+
+// Thread is the type of thread values, each thread runs in its own goroutine
+// with a dynamic environment starting from the bindings in effect where it was made
+type Thread struct {
+	Name    string
+	thunk   func(*DynamicState) (Object, error)
+	state   *DynamicState
+	lock    sync.Mutex
+	started bool
+	done    chan struct{}
+	result  Object
+	err     error
+}
+
+// MakeThread constructs a Thread reference inheriting the bindings of a dynamic environment,
+// once it's started it runs a procedure given the dynamic environment of the new thread
+func MakeThread(parent *DynamicState, thunk func(*DynamicState) (Object, error), name string) (*Thread, error) {
+	if parent == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "parent:", parent)
+	}
+	if thunk == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "thunk:", thunk)
+	}
+	return &Thread{
+		Name:  name,
+		thunk: thunk,
+		state: &DynamicState{
+			env: parent.env,
+		},
+		done: make(chan struct{}),
+	}, nil
+}
+
+// ThreadStart runs a new thread in a goroutine
+func ThreadStart(th *Thread) error {
+	if th == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "th:", th)
+	}
+	th.lock.Lock()
+	defer th.lock.Unlock()
+	if th.started {
+		return errors.NewError(errors.ThreadError, "given an already started thread", "name:", th.Name)
+	}
+	th.started = true
+	go func() {
+		defer close(th.done)
+		defer func() {
+			if r := recover(); r != nil {
+				th.err = errors.NewError(errors.UnexpectedError, "thread panicked", "name:", th.Name, "panic:", fmt.Sprint(r))
+			}
+		}()
+		th.result, th.err = th.thunk(th.state)
+	}()
+	return nil
+}
+
+// ThreadJoin waits for a thread to terminate and returns its result, a negative timeout waits forever.
+// It reports false when the timeout passes first
+func ThreadJoin(th *Thread, timeout time.Duration) (Object, bool, error) {
+	return ThreadJoinContext(context.Background(), th, timeout)
+}
+
+// ThreadJoinContext is ThreadJoin giving up with an InterruptError once a context finishes
+func ThreadJoinContext(ctx context.Context, th *Thread, timeout time.Duration) (Object, bool, error) {
+	if th == nil {
+		return nil, false, errors.NewError(errors.NilError, "given a nil reference", "th:", th)
+	}
+	done, err := wait(ctx, th.done, timeout)
+	if err != nil || !done {
+		return nil, false, err
+	}
+	return th.result, true, th.err
+}
+
+// ThreadYield lets other threads run
+func ThreadYield() {
+	runtime.Gosched()
+}
+
+// ThreadSleep suspends the calling thread for a while
+func ThreadSleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// interrupted returns the channel closed once a context finishes, a nil context never finishes
+func interrupted(ctx context.Context) <-chan struct{} {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Done()
+}
+
+// expiration returns a timer for a timeout and the channel it fires on, a negative timeout
+// never fires and has no timer
+func expiration(timeout time.Duration) (*time.Timer, <-chan time.Time) {
+	if timeout < 0 {
+		return nil, nil
+	}
+	timer := time.NewTimer(timeout)
+	return timer, timer.C
+}
+
+// wait blocks until a channel is closed or receives, a negative timeout waits forever.
+// It reports false when the timeout passes first and an InterruptError when the context finishes first
+func wait(ctx context.Context, ch <-chan struct{}, timeout time.Duration) (bool, error) {
+	select {
+	case <-ch:
+		return true, nil
+	default:
+	}
+	if err := errors.CheckInterrupt(ctx); err != nil {
+		return false, err
+	}
+	timer, expired := expiration(timeout)
+	if timer != nil {
+		defer timer.Stop()
+	}
+	select {
+	case <-ch:
+		return true, nil
+	case <-expired:
+		return false, nil
+	case <-interrupted(ctx):
+		return false, errors.CheckInterrupt(ctx)
+	}
+}
+
+// Mutex is the type of mutex values
+type Mutex struct {
+	Name string
+	held chan struct{}
+}
+
+// MakeMutex constructs an unlocked Mutex reference
+func MakeMutex(name string) *Mutex {
+	return &Mutex{
+		Name: name,
+		held: make(chan struct{}, 1),
+	}
+}
+
+// MutexLock waits until a mutex is unlocked and locks it, a negative timeout waits forever.
+// It reports false when the timeout passes first
+func MutexLock(m *Mutex, timeout time.Duration) (bool, error) {
+	return MutexLockContext(context.Background(), m, timeout)
+}
+
+// MutexLockContext is MutexLock giving up with an InterruptError once a context finishes
+func MutexLockContext(ctx context.Context, m *Mutex, timeout time.Duration) (bool, error) {
+	if m == nil {
+		return false, errors.NewError(errors.NilError, "given a nil reference", "m:", m)
+	}
+	select {
+	case m.held <- struct{}{}:
+		return true, nil
+	default:
+	}
+	if err := errors.CheckInterrupt(ctx); err != nil {
+		return false, err
+	}
+	timer, expired := expiration(timeout)
+	if timer != nil {
+		defer timer.Stop()
+	}
+	select {
+	case m.held <- struct{}{}:
+		return true, nil
+	case <-expired:
+		return false, nil
+	case <-interrupted(ctx):
+		return false, errors.CheckInterrupt(ctx)
+	}
+}
+
+// MutexUnlock unlocks a mutex, when given a condition variable it also waits on it until it's
+// signaled, a negative timeout waits forever. It reports false when the timeout passes first
+func MutexUnlock(m *Mutex, cv *ConditionVariable, timeout time.Duration) (bool, error) {
+	return MutexUnlockContext(context.Background(), m, cv, timeout)
+}
+
+// MutexUnlockContext is MutexUnlock giving up waiting with an InterruptError once a context finishes
+func MutexUnlockContext(ctx context.Context, m *Mutex, cv *ConditionVariable, timeout time.Duration) (bool, error) {
+	if m == nil {
+		return false, errors.NewError(errors.NilError, "given a nil reference", "m:", m)
+	}
+	var signal chan struct{}
+	if cv != nil {
+		signal = cv.enqueue()
+	}
+	select {
+	case <-m.held:
+	default:
+		if cv != nil {
+			cv.dequeue(signal)
+		}
+		return false, errors.NewError(errors.ThreadError, "given an unlocked mutex", "name:", m.Name)
+	}
+	if cv == nil {
+		return true, nil
+	}
+	signaled, err := wait(ctx, signal, timeout)
+	if !signaled {
+		cv.dequeue(signal)
+		select {
+		case <-signal:
+			return true, nil
+		default:
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ConditionVariable is the type of condition variable values
+type ConditionVariable struct {
+	Name    string
+	lock    sync.Mutex
+	waiters []chan struct{}
+}
+
+// MakeConditionVariable constructs a ConditionVariable reference
+func MakeConditionVariable(name string) *ConditionVariable {
+	return &ConditionVariable{
+		Name: name,
+	}
+}
+
+// enqueue registers a new waiter of a condition variable
+func (cv *ConditionVariable) enqueue() chan struct{} {
+	cv.lock.Lock()
+	defer cv.lock.Unlock()
+	signal := make(chan struct{})
+	cv.waiters = append(cv.waiters, signal)
+	return signal
+}
+
+// dequeue forgets a waiter of a condition variable that stopped waiting
+func (cv *ConditionVariable) dequeue(signal chan struct{}) {
+	cv.lock.Lock()
+	defer cv.lock.Unlock()
+	for i, waiter := range cv.waiters {
+		if waiter == signal {
+			cv.waiters = append(cv.waiters[:i], cv.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// ConditionVariableSignal wakes up one of the threads waiting on a condition variable
+func ConditionVariableSignal(cv *ConditionVariable) error {
+	if cv == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "cv:", cv)
+	}
+	cv.lock.Lock()
+	defer cv.lock.Unlock()
+	if len(cv.waiters) > 0 {
+		close(cv.waiters[0])
+		cv.waiters = cv.waiters[1:]
+	}
+	return nil
+}
+
+// ConditionVariableBroadcast wakes up all the threads waiting on a condition variable
+func ConditionVariableBroadcast(cv *ConditionVariable) error {
+	if cv == nil {
+		return errors.NewError(errors.NilError, "given a nil reference", "cv:", cv)
+	}
+	cv.lock.Lock()
+	defer cv.lock.Unlock()
+	for _, waiter := range cv.waiters {
+		close(waiter)
+	}
+	cv.waiters = nil
+	return nil
+}
@@ -0,0 +1,257 @@
+package types
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eduardoacuna/scheme/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThread(t *testing.T) {
+	th, err := MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+		ThreadSleep(time.Millisecond)
+		return GetSymbol("done"), nil
+	}, "worker")
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, reflect.TypeOf(th).Size() <= 8, "byte width should be at most a word")
+
+	_, ok, err := ThreadJoin(th, time.Millisecond)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, ok, "an unstarted thread shouldn't terminate")
+
+	err = ThreadStart(th)
+	assert.NoError(t, err, "it shouldn't be an error")
+	err = ThreadStart(th)
+	assert.Error(t, err, "it should be an error")
+
+	x, ok, err := ThreadJoin(th, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should terminate")
+	assert.Equal(t, GetSymbol("done"), x, "they should be equal")
+
+	failing, _ := MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+		return nil, errors.NewError(errors.ValueError, "failing on purpose")
+	}, "failing")
+	ThreadStart(failing)
+	_, ok, err = ThreadJoin(failing, -1)
+	assert.True(t, ok, "it should terminate")
+	assert.Error(t, err, "it should be an error")
+
+	panicking, _ := MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+		panic("on purpose")
+	}, "panicking")
+	ThreadStart(panicking)
+	_, ok, err = ThreadJoin(panicking, -1)
+	assert.True(t, ok, "it should terminate")
+	assert.Error(t, err, "it should be an error")
+
+	ThreadYield()
+
+	_, err = MakeThread(MainDynamicState(), nil, "nil")
+	assert.Error(t, err, "it should be an error")
+	err = ThreadStart(nil)
+	assert.Error(t, err, "it should be an error")
+	_, _, err = ThreadJoin(nil, -1)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestThreadParameters(t *testing.T) {
+	state := &DynamicState{}
+	param, _ := MakeParameter(GetSymbol("global"), nil)
+	var th *Thread
+	Parameterize(state, []*Parameter{param}, []Object{GetSymbol("inherited")}, func() (Object, error) {
+		th, _ = MakeThread(state, func(state *DynamicState) (Object, error) {
+			return ParameterValue(state, param)
+		}, "inheriting")
+		return nil, nil
+	})
+	ThreadStart(th)
+	x, _, err := ThreadJoin(th, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, GetSymbol("inherited"), x, "it should inherit the bindings where it was made")
+
+	stdout := CurrentOutputPort(state)
+	inside := make(chan struct{})
+	release := make(chan struct{})
+	writer := func(c rune, wait func()) *Thread {
+		th, _ := MakeThread(state, func(state *DynamicState) (Object, error) {
+			return WithOutputToString(state, func() (Object, error) {
+				if err := WriteChar(CurrentOutputPort(state), NewCharacter(c)); err != nil {
+					return nil, err
+				}
+				wait()
+				return nil, nil
+			})
+		}, "writer")
+		return th
+	}
+	a := writer('a', func() {
+		close(inside)
+		<-release
+	})
+	b := writer('b', func() {
+		<-inside
+		close(release)
+	})
+	ThreadStart(a)
+	ThreadStart(b)
+	x, _, err = ThreadJoin(a, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromCharacters([]Character{'a'}), x, "they should be equal")
+	x, _, err = ThreadJoin(b, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, newStringFromCharacters([]Character{'b'}), x, "they should be equal")
+	assert.True(t, CurrentOutputPort(state) == stdout, "it shouldn't change the output port of other threads")
+
+	_, err = MakeThread(nil, func(*DynamicState) (Object, error) {
+		return nil, nil
+	}, "orphan")
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestThreadInterrupt(t *testing.T) {
+	isInterrupt := func(err error) bool {
+		ierr, ok := err.(*errors.InterpreterError)
+		return ok && ierr.Name == errors.InterruptError
+	}
+	release := make(chan struct{})
+	th, _ := MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+		<-release
+		return nil, nil
+	}, "blocked")
+	ThreadStart(th)
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, ok, err := ThreadJoinContext(ctx, th, -1)
+	assert.False(t, ok, "it shouldn't terminate")
+	assert.True(t, isInterrupt(err), "it should be an interrupt error")
+
+	m := MakeMutex("m")
+	MutexLock(m, -1)
+	ok, err = MutexLockContext(ctx, m, -1)
+	assert.False(t, ok, "it shouldn't lock")
+	assert.True(t, isInterrupt(err), "it should be an interrupt error")
+
+	cv := MakeConditionVariable("cv")
+	ok, err = MutexUnlockContext(ctx, m, cv, -1)
+	assert.False(t, ok, "it shouldn't be signaled")
+	assert.True(t, isInterrupt(err), "it should be an interrupt error")
+}
+
+func TestMutex(t *testing.T) {
+	m := MakeMutex("m")
+	counter := 0
+	threads := make([]*Thread, 10)
+	for i := range threads {
+		threads[i], _ = MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+			for j := 0; j < 100; j++ {
+				MutexLock(m, -1)
+				counter++
+				MutexUnlock(m, nil, -1)
+			}
+			return nil, nil
+		}, "incrementer")
+		ThreadStart(threads[i])
+	}
+	for _, th := range threads {
+		ThreadJoin(th, -1)
+	}
+	assert.Equal(t, 1000, counter, "they should be equal")
+
+	ok, err := MutexLock(m, 0)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should lock")
+	ok, err = MutexLock(m, time.Millisecond)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, ok, "it should time out")
+	ok, err = MutexUnlock(m, nil, -1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should unlock")
+
+	_, err = MutexUnlock(m, nil, -1)
+	assert.Error(t, err, "it should be an error")
+	_, err = MutexLock(nil, -1)
+	assert.Error(t, err, "it should be an error")
+	_, err = MutexUnlock(nil, nil, -1)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestConditionVariable(t *testing.T) {
+	m := MakeMutex("m")
+	cv := MakeConditionVariable("cv")
+	ready := false
+
+	consumer, _ := MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+		MutexLock(m, -1)
+		for !ready {
+			MutexUnlock(m, cv, -1)
+			MutexLock(m, -1)
+		}
+		MutexUnlock(m, nil, -1)
+		return True(), nil
+	}, "consumer")
+	ThreadStart(consumer)
+
+	MutexLock(m, -1)
+	ready = true
+	MutexUnlock(m, nil, -1)
+	err := ConditionVariableBroadcast(cv)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	x, ok, err := ThreadJoin(consumer, time.Second)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, ok, "it should terminate")
+	assert.Equal(t, True(), x, "they should be equal")
+
+	MutexLock(m, -1)
+	ok, err = MutexUnlock(m, cv, time.Millisecond)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.False(t, ok, "it should time out")
+
+	waiter, _ := MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+		MutexLock(m, -1)
+		ok, err := MutexUnlock(m, cv, -1)
+		return Boolean(ok), err
+	}, "waiter")
+	ThreadStart(waiter)
+	_, ok, _ = ThreadJoin(waiter, 10*time.Millisecond)
+	assert.False(t, ok, "it should be waiting")
+	for {
+		ConditionVariableSignal(cv)
+		x, ok, err = ThreadJoin(waiter, time.Millisecond)
+		if ok {
+			break
+		}
+	}
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, True(), x, "they should be equal")
+
+	_, err = MutexUnlock(m, cv, -1)
+	assert.Error(t, err, "it should be an error")
+	err = ConditionVariableSignal(nil)
+	assert.Error(t, err, "it should be an error")
+	err = ConditionVariableBroadcast(nil)
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestConcurrentSymbols(t *testing.T) {
+	threads := make([]*Thread, 8)
+	for i := range threads {
+		threads[i], _ = MakeThread(MainDynamicState(), func(*DynamicState) (Object, error) {
+			return GetSymbol("shared"), nil
+		}, "interner")
+		ThreadStart(threads[i])
+	}
+	for _, th := range threads {
+		x, _, _ := ThreadJoin(th, -1)
+		assert.True(t, x == GetSymbol("shared"), "they should be the same")
+	}
+}
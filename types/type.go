@@ -1,7 +1,10 @@
 package types
 
 import (
-	"io"
+	"math"
+	"reflect"
+	"sync"
+	"unsafe"
 
 	"github.com/eduardoacuna/scheme/errors"
 )
@@ -113,6 +116,18 @@ func Cdr(cons *Pair) (Object, error) {
 	return cons.Cdr, nil
 }
 
+// newList constructs a proper list with the given elements
+func newList(elms []Object) Object {
+	var list Object = Null()
+	for i := len(elms) - 1; i >= 0; i-- {
+		list = &Pair{
+			Car: elms[i],
+			Cdr: list,
+		}
+	}
+	return list
+}
+
 // Symbol is the type of symbol values
 type Symbol struct {
 	Name string
@@ -121,8 +136,13 @@ type Symbol struct {
 // symbolTable is the global mapping of strings to symbol values
 var symbolTable = map[string]*Symbol{}
 
+// symbolTableLock serializes the access to the symbol table between threads
+var symbolTableLock sync.Mutex
+
 // GetSymbol takes a string and returns it's corresponding symbol value
 func GetSymbol(name string) *Symbol {
+	symbolTableLock.Lock()
+	defer symbolTableLock.Unlock()
 	sym, ok := symbolTable[name]
 	if !ok {
 		sym = &Symbol{
@@ -141,30 +161,6 @@ func SymbolName(sym *Symbol) (string, error) {
 	return sym.Name, nil
 }
 
-// InputPort is the type of input port values
-type InputPort struct {
-	Reader io.Reader
-}
-
-// NewInputPort constructs an InputPort reference
-func NewInputPort(reader io.Reader) *InputPort {
-	return &InputPort{
-		Reader: reader,
-	}
-}
-
-// OutputPort is the type of output port values
-type OutputPort struct {
-	Writer io.Writer
-}
-
-// NewOutputPort constructs an OutputPort reference
-func NewOutputPort(writer io.Writer) *OutputPort {
-	return &OutputPort{
-		Writer: writer,
-	}
-}
-
 // String is the type of string values
 type String struct {
 	Elements []Character
@@ -302,3 +298,276 @@ func ByteVectorSet(bv *ByteVector, i int, n Fixnum) error {
 	bv.Elements[i] = byte(n)
 	return nil
 }
+
+// Mark is the type of the marks telling apart the identifiers introduced by each macro expansion,
+// marks are compared by reference and the name is only for printing
+type Mark struct {
+	Name string
+}
+
+// Substitution is a renaming of the identifiers with a name and a set of marks to a binding label
+type Substitution struct {
+	Name  *Symbol
+	Marks []*Mark
+	Label *Symbol
+}
+
+// Wrap is the lexical context of a syntax object, the marks of the expansions that introduced it
+// and the substitutions of the binding forms it's inside, the innermost ones first
+type Wrap struct {
+	Marks         []*Mark
+	Substitutions []*Substitution
+}
+
+// Syntax is the type of syntax objects, a datum annotated with its source location and lexical context,
+// a nil wrap is the empty context of code read from a source
+type Syntax struct {
+	Datum  Object
+	Source string
+	Line   int
+	Column int
+	Wrap   *Wrap
+}
+
+// NewSyntax constructs a Syntax reference
+func NewSyntax(datum Object, source string, line, column int) (*Syntax, error) {
+	if line < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a line < 0", "line:", line)
+	}
+	if column < 0 {
+		return nil, errors.NewError(errors.ValueError, "given a column < 0", "column:", column)
+	}
+	return &Syntax{
+		Datum:  datum,
+		Source: source,
+		Line:   line,
+		Column: column,
+	}, nil
+}
+
+// SyntaxToDatum strips the syntax objects from a value, descending into pairs and vectors
+func SyntaxToDatum(x Object) (Object, error) {
+	switch x := x.(type) {
+	case *Syntax:
+		if x == nil {
+			return nil, errors.NewError(errors.NilError, "given a nil reference", "stx:", x)
+		}
+		return SyntaxToDatum(x.Datum)
+	case *Pair:
+		if x == nil {
+			return nil, errors.NewError(errors.NilError, "given a nil reference", "cons:", x)
+		}
+		car, err := SyntaxToDatum(x.Car)
+		if err != nil {
+			return nil, err
+		}
+		cdr, err := SyntaxToDatum(x.Cdr)
+		if err != nil {
+			return nil, err
+		}
+		return NewPair(car, cdr)
+	case *Vector:
+		if x == nil {
+			return nil, errors.NewError(errors.NilError, "given a nil reference", "vec:", x)
+		}
+		vec, err := NewVector(x.Length, Undefined())
+		if err != nil {
+			return nil, err
+		}
+		for i, elm := range x.Elements {
+			vec.Elements[i], err = SyntaxToDatum(elm)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return vec, nil
+	}
+	return x, nil
+}
+
+// DatumToSyntax wraps a datum in a syntax object with the location and lexical context
+// of a context syntax object
+func DatumToSyntax(context *Syntax, datum Object) (*Syntax, error) {
+	if context == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "context:", context)
+	}
+	if stx, ok := datum.(*Syntax); ok {
+		return stx, nil
+	}
+	stx, err := NewSyntax(datum, context.Source, context.Line, context.Column)
+	if err != nil {
+		return nil, err
+	}
+	stx.Wrap = context.Wrap
+	return stx, nil
+}
+
+// Values is the type of multiple return values
+type Values struct {
+	Elements []Object
+	Length   int
+}
+
+// NewValues constructs a Values reference
+func NewValues(elements ...Object) *Values {
+	elms := make([]Object, len(elements))
+	copy(elms, elements)
+	return &Values{
+		Elements: elms,
+		Length:   len(elms),
+	}
+}
+
+// ValuesRef returns the object at a values position
+func ValuesRef(vals *Values, i int) (Object, error) {
+	if vals == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "vals:", vals)
+	}
+	if i < 0 || i >= len(vals.Elements) {
+		return nil, errors.NewError(errors.OutOfBoundsError, "given a bad values index", "i:", i)
+	}
+	return vals.Elements[i], nil
+}
+
+// ValuesList returns the objects delivered by a value, a single value delivers itself
+func ValuesList(x Object) ([]Object, error) {
+	vals, ok := x.(*Values)
+	if !ok {
+		return []Object{x}, nil
+	}
+	if vals == nil {
+		return nil, errors.NewError(errors.NilError, "given a nil reference", "vals:", vals)
+	}
+	return vals.Elements, nil
+}
+
+// Eqv reports whether two objects are operationally equivalent, procedures given as Go functions
+// are the same when they share their closure
+func Eqv(x, y Object) bool {
+	fx, ok := x.(Flonum)
+	if ok {
+		fy, ok := y.(Flonum)
+		return ok && math.Float64bits(float64(fx)) == math.Float64bits(float64(fy))
+	}
+	t := reflect.TypeOf(x)
+	if t != reflect.TypeOf(y) {
+		return false
+	}
+	if t == nil || t.Comparable() {
+		return x == y
+	}
+	if t.Kind() == reflect.Func {
+		return funcIdentity(x) == funcIdentity(y)
+	}
+	return false
+}
+
+// funcIdentity returns the address of the closure of a function held by an object,
+// function values can't be compared with == and their code pointer is shared by every closure
+// made from the same function literal
+func funcIdentity(x Object) uintptr {
+	return (*[2]uintptr)(unsafe.Pointer(&x))[1]
+}
+
+// Equal reports whether two objects are eqv or have equal contents, it terminates on circular structures
+func Equal(x, y Object) bool {
+	if Eqv(x, y) {
+		return true
+	}
+	st := equalState{}
+	return equal(x, y, &st)
+}
+
+// equalState is a union-find over the pairs and vectors assumed equal so far,
+// comparing a couple a second time succeeds so cycles stop there. It's made on the first assumption
+type equalState struct {
+	parent map[Object]Object
+}
+
+// find returns the representative of the class of an object
+func (st *equalState) find(x Object) Object {
+	for {
+		p, ok := st.parent[x]
+		if !ok {
+			return x
+		}
+		if gp, ok := st.parent[p]; ok {
+			st.parent[x] = gp
+		}
+		x = p
+	}
+}
+
+// assume reports whether two objects were already assumed equal and assumes it from now on
+func (st *equalState) assume(x, y Object) bool {
+	rx, ry := st.find(x), st.find(y)
+	if rx == ry {
+		return true
+	}
+	if st.parent == nil {
+		st.parent = map[Object]Object{}
+	}
+	st.parent[rx] = ry
+	return false
+}
+
+// equal compares the contents of two objects, the cdrs of pairs are followed iteratively
+func equal(x, y Object, st *equalState) bool {
+	for {
+		if Eqv(x, y) {
+			return true
+		}
+		switch xx := x.(type) {
+		case *Pair:
+			yy, ok := y.(*Pair)
+			if !ok || xx == nil || yy == nil {
+				return false
+			}
+			if st.assume(xx, yy) {
+				return true
+			}
+			if !equal(xx.Car, yy.Car, st) {
+				return false
+			}
+			x, y = xx.Cdr, yy.Cdr
+		case *Vector:
+			yy, ok := y.(*Vector)
+			if !ok || xx == nil || yy == nil || len(xx.Elements) != len(yy.Elements) {
+				return false
+			}
+			if st.assume(xx, yy) {
+				return true
+			}
+			for i := range xx.Elements {
+				if !equal(xx.Elements[i], yy.Elements[i], st) {
+					return false
+				}
+			}
+			return true
+		case *String:
+			yy, ok := y.(*String)
+			if !ok || xx == nil || yy == nil || len(xx.Elements) != len(yy.Elements) {
+				return false
+			}
+			for i := range xx.Elements {
+				if xx.Elements[i] != yy.Elements[i] {
+					return false
+				}
+			}
+			return true
+		case *ByteVector:
+			yy, ok := y.(*ByteVector)
+			if !ok || xx == nil || yy == nil || len(xx.Elements) != len(yy.Elements) {
+				return false
+			}
+			for i := range xx.Elements {
+				if xx.Elements[i] != yy.Elements[i] {
+					return false
+				}
+			}
+			return true
+		default:
+			return false
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -344,3 +345,176 @@ func TestByteVector(t *testing.T) {
 	err = ByteVectorSet(bv, 0, 256)
 	assert.Error(t, err, "it should be an error")
 }
+
+func TestSyntax(t *testing.T) {
+	stx, err := NewSyntax(GetSymbol("foo"), "foo.scm", 3, 7)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	_, err = NewSyntax(GetSymbol("foo"), "foo.scm", -1, 7)
+	assert.Error(t, err, "it should be an error")
+	_, err = NewSyntax(GetSymbol("foo"), "foo.scm", 3, -1)
+	assert.Error(t, err, "it should be an error")
+
+	assert.True(t, reflect.TypeOf(stx).Size() <= 8, "byte width should be at most a word")
+	assert.Equal(t, "foo.scm", stx.Source, "they should be equal")
+	assert.Equal(t, 3, stx.Line, "they should be equal")
+	assert.Equal(t, 7, stx.Column, "they should be equal")
+
+	datum, err := SyntaxToDatum(stx)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, datum == GetSymbol("foo"), "they should be the same")
+
+	datum, err = SyntaxToDatum(NewFixnum(1))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, NewFixnum(1), datum, "they should be equal")
+
+	one, _ := NewSyntax(NewFixnum(1), "foo.scm", 3, 8)
+	cons, _ := NewPair(stx, one)
+	vec, _ := NewVector(2, cons)
+	datum, err = SyntaxToDatum(vec)
+	assert.NoError(t, err, "it shouldn't be an error")
+	expected, _ := NewPair(GetSymbol("foo"), NewFixnum(1))
+	expectedVec, _ := NewVector(2, expected)
+	assert.Equal(t, expectedVec, datum, "they should be equal")
+	assert.True(t, cons.Car == stx, "the original should be untouched")
+
+	_, err = SyntaxToDatum((*Syntax)(nil))
+	assert.Error(t, err, "it should be an error")
+
+	wrapped, err := DatumToSyntax(stx, GetSymbol("bar"))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, wrapped.Datum == GetSymbol("bar"), "they should be the same")
+	assert.Equal(t, stx.Source, wrapped.Source, "they should be equal")
+	assert.Equal(t, stx.Line, wrapped.Line, "they should be equal")
+	assert.Equal(t, stx.Column, wrapped.Column, "they should be equal")
+	assert.Nil(t, wrapped.Wrap, "it should have the empty context")
+
+	expansion := &Mark{Name: "m1"}
+	stx.Wrap = &Wrap{
+		Marks: []*Mark{expansion},
+		Substitutions: []*Substitution{{
+			Name:  GetSymbol("foo"),
+			Marks: []*Mark{expansion},
+			Label: GetSymbol("foo.1"),
+		}},
+	}
+	wrapped, err = DatumToSyntax(stx, GetSymbol("bar"))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, wrapped.Wrap == stx.Wrap, "it should take the lexical context")
+
+	same, err := DatumToSyntax(stx, one)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.True(t, same == one, "they should be the same")
+
+	_, err = DatumToSyntax(nil, GetSymbol("bar"))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestValues(t *testing.T) {
+	vals := NewValues(NewFixnum(1), True(), NewCharacter('v'))
+	vals2 := NewValues(NewFixnum(1), True(), NewCharacter('v'))
+
+	assert.True(t, reflect.TypeOf(vals).Size() <= 8, "byte width should be at most a word")
+	assert.Equal(t, vals, vals2, "they should be equal")
+	assert.False(t, vals == vals2, "they shouldn't be the same")
+	assert.Equal(t, 3, vals.Length, "they should be equal")
+	assert.Equal(t, 0, NewValues().Length, "they should be equal")
+
+	x0, err := ValuesRef(vals, 0)
+	assert.NoError(t, err, "it shouldn't be an error")
+	x1, err := ValuesRef(vals, 1)
+	assert.NoError(t, err, "it shouldn't be an error")
+	x2, err := ValuesRef(vals, 2)
+	assert.NoError(t, err, "it shouldn't be an error")
+
+	assert.Equal(t, NewFixnum(1), x0, "they should be equal")
+	assert.Equal(t, True(), x1, "they should be equal")
+	assert.Equal(t, NewCharacter('v'), x2, "they should be equal")
+
+	_, err = ValuesRef(nil, 0)
+	assert.Error(t, err, "it should be an error")
+	_, err = ValuesRef(vals, -1)
+	assert.Error(t, err, "it should be an error")
+	_, err = ValuesRef(vals, 3)
+	assert.Error(t, err, "it should be an error")
+
+	elms, err := ValuesList(vals)
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, []Object{NewFixnum(1), True(), NewCharacter('v')}, elms, "they should be equal")
+
+	elms, err = ValuesList(NewFixnum(7))
+	assert.NoError(t, err, "it shouldn't be an error")
+	assert.Equal(t, []Object{NewFixnum(7)}, elms, "they should be equal")
+
+	_, err = ValuesList((*Values)(nil))
+	assert.Error(t, err, "it should be an error")
+}
+
+func TestEquivalence(t *testing.T) {
+	cons1, _ := NewPair(NewFixnum(1), NewCharacter('a'))
+	cons2, _ := NewPair(NewFixnum(1), NewCharacter('a'))
+	str1, _ := NewString(3, NewCharacter('x'))
+	str2, _ := NewString(3, NewCharacter('x'))
+	str3, _ := NewString(2, NewCharacter('x'))
+	vec1, _ := NewVector(2, cons1)
+	vec2, _ := NewVector(2, cons2)
+	bv1, _ := NewByteVector(2, NewFixnum(7))
+	bv2, _ := NewByteVector(2, NewFixnum(7))
+	bv3, _ := NewByteVector(2, NewFixnum(8))
+	nan := NewFlonum(math.NaN())
+
+	assert.True(t, Eqv(NewFixnum(1), NewFixnum(1)), "they should be eqv")
+	assert.True(t, Eqv(nan, nan), "they should be eqv")
+	assert.True(t, Eqv(GetSymbol("a"), GetSymbol("a")), "they should be eqv")
+	assert.True(t, Eqv(cons1, cons1), "they should be eqv")
+	assert.False(t, Eqv(NewFixnum(1), NewFlonum(1)), "they shouldn't be eqv")
+	assert.False(t, Eqv(NewFlonum(0), NewFlonum(math.Copysign(0, -1))), "they shouldn't be eqv")
+	assert.False(t, Eqv(cons1, cons2), "they shouldn't be eqv")
+	assert.False(t, Eqv(str1, str2), "they shouldn't be eqv")
+
+	adder := func(n int) func(int) int {
+		return func(m int) int {
+			return n + m
+		}
+	}
+	add1, add2 := adder(1), adder(2)
+	assert.True(t, Eqv(add1, add1), "they should be eqv")
+	assert.False(t, Eqv(add1, add2), "they shouldn't be eqv")
+	assert.False(t, Eqv(add1, NewFixnum(1)), "they shouldn't be eqv")
+	assert.False(t, Eqv([]int{1}, []int{1}), "they shouldn't be eqv")
+
+	assert.True(t, Equal(cons1, cons2), "they should be equal")
+	assert.True(t, Equal(str1, str2), "they should be equal")
+	assert.True(t, Equal(vec1, vec2), "they should be equal")
+	assert.True(t, Equal(bv1, bv2), "they should be equal")
+	assert.True(t, Equal(Null(), Null()), "they should be equal")
+	assert.False(t, Equal(str1, str3), "they shouldn't be equal")
+	assert.False(t, Equal(bv1, bv3), "they shouldn't be equal")
+	assert.False(t, Equal(cons1, vec1), "they shouldn't be equal")
+	assert.False(t, Equal(NewFixnum(1), NewFixnum(2)), "they shouldn't be equal")
+
+	circular1, _ := NewPair(NewFixnum(1), Null())
+	circular1.Cdr = circular1
+	circular2, _ := NewPair(NewFixnum(1), Null())
+	circular2.Cdr = circular2
+	unrolled, _ := NewPair(NewFixnum(1), circular2)
+	other, _ := NewPair(NewFixnum(2), Null())
+	other.Cdr = other
+	assert.True(t, Equal(circular1, circular2), "they should be equal")
+	assert.True(t, Equal(circular1, unrolled), "they should be equal")
+	assert.False(t, Equal(circular1, other), "they shouldn't be equal")
+
+	self1, _ := NewVector(2, Null())
+	self1.Elements[0] = self1
+	self2, _ := NewVector(2, Null())
+	self2.Elements[0] = self2
+	assert.True(t, Equal(self1, self2), "they should be equal")
+	self2.Elements[1] = NewFixnum(1)
+	assert.False(t, Equal(self1, self2), "they shouldn't be equal")
+
+	allocs := testing.AllocsPerRun(10, func() {
+		Equal(str1, str2)
+		Equal(NewFixnum(1), NewFixnum(2))
+	})
+	assert.Equal(t, 0.0, allocs, "it shouldn't allocate without pairs or vectors")
+}